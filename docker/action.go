@@ -0,0 +1,146 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+
+	apiContainer "github.com/docker/docker/api/types/container"
+
+	"github.com/syrm/c8s/tui"
+)
+
+func (d *Docker) handleRequestContainerAction(ctx context.Context, r *tui.RequestContainerAction) {
+	err := d.containerAction(ctx, string(r.ContainerID), r.Action)
+	if err != nil {
+		d.logger.ErrorContext(ctx, "container action failed", slog.String("container_id", string(r.ContainerID)), slog.String("action", r.Action.String()), slog.Any("error", err))
+	}
+
+	r.Response <- err
+}
+
+func (d *Docker) containerAction(ctx context.Context, containerID string, action tui.ContainerAction) error {
+	switch action {
+	case tui.ContainerActionStart:
+		return d.client.ContainerStart(ctx, containerID, apiContainer.StartOptions{})
+	case tui.ContainerActionStop:
+		return d.client.ContainerStop(ctx, containerID, apiContainer.StopOptions{})
+	case tui.ContainerActionRestart:
+		return d.client.ContainerRestart(ctx, containerID, apiContainer.StopOptions{})
+	case tui.ContainerActionPause:
+		return d.client.ContainerPause(ctx, containerID)
+	case tui.ContainerActionUnpause:
+		return d.client.ContainerUnpause(ctx, containerID)
+	case tui.ContainerActionKill:
+		return d.client.ContainerKill(ctx, containerID, "SIGKILL")
+	case tui.ContainerActionRemove:
+		return d.client.ContainerRemove(ctx, containerID, apiContainer.RemoveOptions{Force: true})
+	}
+
+	return fmt.Errorf("unknown container action %d", action)
+}
+
+func (d *Docker) handleRequestProjectAction(ctx context.Context, r *tui.RequestProjectAction) {
+	// The grouping may have changed since the user confirmed, the containers are found by ID.
+	containers := d.containersByID(r.ContainerIDs)
+	if len(containers) == 0 {
+		close(r.Progress)
+		r.Response <- errors.New("none of the containers exist anymore")
+		return
+	}
+
+	levels := dependencyLevels(containers)
+
+	// Dependencies are started first and stopped last, like compose does.
+	if r.Action == tui.ContainerActionStop || r.Action == tui.ContainerActionRemove {
+		slices.Reverse(levels)
+	}
+
+	var (
+		errs   []error
+		errsMu sync.Mutex
+	)
+
+	for _, level := range levels {
+		var wg sync.WaitGroup
+
+		for _, container := range level {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				err := d.containerAction(ctx, string(container.ID), r.Action)
+				if err != nil {
+					d.logger.ErrorContext(ctx, "project action failed", slog.String("container_id", string(container.ID)), slog.String("action", r.Action.String()), slog.Any("error", err))
+
+					errsMu.Lock()
+					errs = append(errs, fmt.Errorf("%s: %w", container.Name, err))
+					errsMu.Unlock()
+				}
+
+				r.Progress <- tui.ProjectActionProgress{
+					ContainerName: container.Name,
+					Total:         len(containers),
+					Err:           err,
+				}
+			}()
+		}
+
+		wg.Wait()
+	}
+
+	close(r.Progress)
+
+	r.Response <- errors.Join(errs...)
+}
+
+func dependencyLevels(containers []ContainerResponse) [][]ContainerResponse {
+	byService := make(map[string][]ContainerResponse, len(containers))
+	for _, c := range containers {
+		byService[c.Service] = append(byService[c.Service], c)
+	}
+
+	depth := make(map[string]int, len(byService))
+	visiting := make(map[string]bool, len(byService))
+
+	var serviceDepth func(service string) int
+	serviceDepth = func(service string) int {
+		if d, ok := depth[service]; ok {
+			return d
+		}
+
+		// A dependency cycle is broken by considering the service has no dependency left.
+		if visiting[service] {
+			return 0
+		}
+		visiting[service] = true
+
+		d := 0
+		for _, c := range byService[service] {
+			for _, dependency := range c.DependsOn {
+				if _, exists := byService[dependency]; exists {
+					d = max(d, serviceDepth(dependency)+1)
+				}
+			}
+		}
+
+		depth[service] = d
+
+		return d
+	}
+
+	var levels [][]ContainerResponse
+	for service, serviceContainers := range byService {
+		d := serviceDepth(service)
+		for len(levels) <= d {
+			levels = append(levels, nil)
+		}
+
+		levels[d] = append(levels[d], serviceContainers...)
+	}
+
+	return levels
+}
@@ -0,0 +1,83 @@
+package docker
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDependencyLevels(t *testing.T) {
+	tests := []struct {
+		name       string
+		containers []ContainerResponse
+		want       [][]string
+	}{
+		{
+			name: "no dependency",
+			containers: []ContainerResponse{
+				{Name: "web", Service: "web"},
+				{Name: "db", Service: "db"},
+			},
+			want: [][]string{{"db", "web"}},
+		},
+		{
+			name: "chain",
+			containers: []ContainerResponse{
+				{Name: "web", Service: "web", DependsOn: []string{"api"}},
+				{Name: "api", Service: "api", DependsOn: []string{"db"}},
+				{Name: "db", Service: "db"},
+			},
+			want: [][]string{{"db"}, {"api"}, {"web"}},
+		},
+		{
+			name: "replicas share the level of their service",
+			containers: []ContainerResponse{
+				{Name: "web-1", Service: "web", DependsOn: []string{"db"}},
+				{Name: "web-2", Service: "web", DependsOn: []string{"db"}},
+				{Name: "db", Service: "db"},
+			},
+			want: [][]string{{"db"}, {"web-1", "web-2"}},
+		},
+		{
+			name: "dependency outside the containers is ignored",
+			containers: []ContainerResponse{
+				{Name: "web", Service: "web", DependsOn: []string{"db"}},
+			},
+			want: [][]string{{"web"}},
+		},
+		{
+			name: "cycle",
+			containers: []ContainerResponse{
+				{Name: "a", Service: "a", DependsOn: []string{"b"}},
+				{Name: "b", Service: "b", DependsOn: []string{"a"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			levels := dependencyLevels(tt.containers)
+
+			var got [][]string
+			for _, level := range levels {
+				var names []string
+				for _, c := range level {
+					names = append(names, c.Name)
+				}
+				slices.Sort(names)
+				got = append(got, names)
+			}
+
+			// A cycle is broken on whichever service is visited first, only check every container is kept once.
+			if tt.want == nil {
+				if len(slices.Concat(got...)) != len(tt.containers) {
+					t.Fatalf("dependencyLevels() = %v, want every container once", got)
+				}
+				return
+			}
+
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("dependencyLevels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,8 +2,11 @@ package docker
 
 import (
 	"context"
-	"errors"
 	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
 
 	apiContainer "github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/events"
@@ -13,27 +16,74 @@ import (
 
 type ContainerID string
 
+// standaloneProjectID can't collide with compose projects, their ID being an absolute working directory.
+const standaloneProjectID dto.ProjectID = "standalone"
+
 type Container struct {
-	ID               ContainerID
-	Service          string
-	Name             string
-	Project          dto.ContainerProject
-	CPUPercentage    float64
-	MemoryPercentage float64
-	IsRunning        bool
-	Command          chan ContainerCommand
-	cancel           context.CancelFunc
-	logger           *slog.Logger
+	ID                  ContainerID
+	Service             string
+	Name                string
+	Image               string
+	Labels              map[string]string
+	Networks            []string
+	Project             dto.ContainerProject
+	DependsOn           []string
+	CPUPercentage       float64
+	MemoryPercentage    float64
+	MemoryUsage         float64
+	MemoryLimit         float64
+	NetworkRxRate       float64
+	NetworkTxRate       float64
+	BlockReadRate       float64
+	BlockWriteRate      float64
+	PidsCurrent         uint64
+	PidsLimit           uint64
+	ThrottledPercentage float64
+	ThrottledTimeRate   float64
+	Health              dto.HealthStatus
+	State               dto.ContainerState
+	ExitCode            int
+	OOMKilled           bool
+	StartedAt           time.Time
+	RestartCount        int
+	Command             chan ContainerCommand
+	history             []dto.MetricSample
+	dies                []time.Time
+	healthcheck         bool
+	ioCounters          ioCounters
+	cancel              context.CancelFunc
+	done                <-chan struct{}
+	logger              *slog.Logger
 }
 
 type ContainerResponse struct {
-	ID               ContainerID
-	Project          dto.ContainerProject
-	Service          string
-	Name             string
-	CPUPercentage    float64
-	MemoryPercentage float64
-	IsRunning        bool
+	ID                  ContainerID
+	Project             dto.ContainerProject
+	Service             string
+	Name                string
+	Image               string
+	Labels              map[string]string
+	Networks            []string
+	DependsOn           []string
+	CPUPercentage       float64
+	MemoryPercentage    float64
+	MemoryUsage         float64
+	MemoryLimit         float64
+	NetworkRxRate       float64
+	NetworkTxRate       float64
+	BlockReadRate       float64
+	BlockWriteRate      float64
+	PidsCurrent         uint64
+	PidsLimit           uint64
+	ThrottledPercentage float64
+	ThrottledTimeRate   float64
+	Health              dto.HealthStatus
+	State               dto.ContainerState
+	ExitCode            int
+	OOMKilled           bool
+	StartedAt           time.Time
+	RestartCount        int
+	CrashLooping        bool
 }
 
 type ContainerCommand struct {
@@ -41,6 +91,14 @@ type ContainerCommand struct {
 	response chan ContainerResponse
 }
 
+type ioCounters struct {
+	read       time.Time
+	networkRx  uint64
+	networkTx  uint64
+	blockRead  uint64
+	blockWrite uint64
+}
+
 func NewContainer(
 	ctx context.Context,
 	dockerContainer apiContainer.Summary,
@@ -51,21 +109,29 @@ func NewContainer(
 	ctx, cancel := context.WithCancel(ctx)
 
 	c := &Container{
-		ID:      ContainerID(dockerContainer.ID),
-		Service: dockerContainer.Labels["com.docker.compose.service"],
-		Name:    dockerContainer.Names[0],
-		Command: make(chan ContainerCommand),
-		Project: project,
-		cancel:  cancel,
-		logger:  logger,
+		ID:        ContainerID(dockerContainer.ID),
+		Service:   containerService(dockerContainer),
+		Name:      containerName(dockerContainer),
+		Image:     dockerContainer.Image,
+		Labels:    dockerContainer.Labels,
+		Networks:  containerNetworks(dockerContainer),
+		Command:   make(chan ContainerCommand),
+		Project:   project,
+		DependsOn: parseDependsOn(dockerContainer.Labels["com.docker.compose.depends_on"]),
+		Health:    healthFromStatus(dockerContainer.Status),
+		cancel:    cancel,
+		done:      ctx.Done(),
+		logger:    logger,
 	}
 
-	isRunning, err := isRunningFromAction(action)
-
-	if err == nil {
-		c.IsRunning = isRunning
+	// Containers created from an event have no state yet, their labels are the event attributes.
+	c.State = dto.ContainerState(dockerContainer.State)
+	if c.State == "" {
+		c.SetStateFromEvent(action, dockerContainer.Labels, time.Now())
 	}
 
+	c.healthcheck = c.Health != dto.HealthNone
+
 	go c.handleCommands(ctx)
 
 	return c
@@ -83,67 +149,222 @@ func (c *Container) handleCommands(ctx context.Context) {
 
 			if cmd.response != nil {
 				cmd.response <- ContainerResponse{
-					ID:               c.ID,
-					Project:          c.Project,
-					Name:             c.Name,
-					Service:          c.Service,
-					CPUPercentage:    c.CPUPercentage,
-					MemoryPercentage: c.MemoryPercentage,
-					IsRunning:        c.IsRunning,
+					ID:                  c.ID,
+					Project:             c.Project,
+					Name:                c.Name,
+					Service:             c.Service,
+					Image:               c.Image,
+					Labels:              c.Labels,
+					Networks:            slices.Clone(c.Networks),
+					DependsOn:           c.DependsOn,
+					CPUPercentage:       c.CPUPercentage,
+					MemoryPercentage:    c.MemoryPercentage,
+					MemoryUsage:         c.MemoryUsage,
+					MemoryLimit:         c.MemoryLimit,
+					NetworkRxRate:       c.NetworkRxRate,
+					NetworkTxRate:       c.NetworkTxRate,
+					BlockReadRate:       c.BlockReadRate,
+					BlockWriteRate:      c.BlockWriteRate,
+					PidsCurrent:         c.PidsCurrent,
+					PidsLimit:           c.PidsLimit,
+					ThrottledPercentage: c.ThrottledPercentage,
+					ThrottledTimeRate:   c.ThrottledTimeRate,
+					Health:              c.Health,
+					State:               c.State,
+					ExitCode:            c.ExitCode,
+					OOMKilled:           c.OOMKilled,
+					StartedAt:           c.StartedAt,
+					RestartCount:        c.RestartCount,
+					CrashLooping:        c.crashLooping(time.Now()),
 				}
 			}
 		}
 	}
 }
 
+// parseDependsOn extracts service names from a compose label like "db:service_started:false,cache:service_healthy:true".
+func parseDependsOn(label string) []string {
+	if label == "" {
+		return nil
+	}
+
+	var services []string
+	for dependency := range strings.SplitSeq(label, ",") {
+		service, _, _ := strings.Cut(dependency, ":")
+		if service != "" {
+			services = append(services, service)
+		}
+	}
+
+	return services
+}
+
+// containerService falls back to the container name for containers started without compose.
+func containerService(dockerContainer apiContainer.Summary) string {
+	if service := dockerContainer.Labels["com.docker.compose.service"]; service != "" {
+		return service
+	}
+
+	return containerName(dockerContainer)
+}
+
+func containerName(dockerContainer apiContainer.Summary) string {
+	if len(dockerContainer.Names) == 0 {
+		return dockerContainer.ID
+	}
+
+	return strings.TrimPrefix(dockerContainer.Names[0], "/")
+}
+
+func containerNetworks(dockerContainer apiContainer.Summary) []string {
+	if dockerContainer.NetworkSettings == nil {
+		return nil
+	}
+
+	return slices.Sorted(maps.Keys(dockerContainer.NetworkSettings.Networks))
+}
+
+func (c *Container) SetNetworkFromAction(network string, action events.Action) {
+	c.Networks = slices.DeleteFunc(c.Networks, func(n string) bool { return n == network })
+
+	if action == events.ActionConnect {
+		c.Networks = append(c.Networks, network)
+		slices.Sort(c.Networks)
+	}
+}
+
 func (c *Container) Delete() {
 	c.cancel()
 }
 
-func (c *Container) setRunningStateFromState(containerState apiContainer.ContainerState) {
-	c.IsRunning = containerState == apiContainer.StateRunning
+// send gives up once the container is deleted, nothing reads its commands anymore.
+func (c *Container) send(cmd ContainerCommand) bool {
+	select {
+	case c.Command <- cmd:
+		return true
+	case <-c.done:
+		return false
+	}
+}
+
+func (c *Container) Update(stats apiContainer.StatsResponse) {
+	c.updateCPUPercent(stats.CPUStats, stats.PreCPUStats)
+	c.updateMemoryPercentage(stats.MemoryStats)
+	c.updateIORates(stats)
+	c.updatePids(stats.PidsStats)
+	c.updateThrottling(stats)
+}
+
+// recordHistory records zeros while the container is not up.
+func (c *Container) recordHistory(now time.Time) {
+	sample := dto.MetricSample{Time: now}
+	if c.State == dto.StateRunning || c.State == dto.StatePaused {
+		sample = dto.MetricSample{
+			Time:             now,
+			CPUPercentage:    c.CPUPercentage,
+			MemoryPercentage: c.MemoryPercentage,
+			MemoryUsage:      c.MemoryUsage,
+			NetworkRxRate:    c.NetworkRxRate,
+			NetworkTxRate:    c.NetworkTxRate,
+			BlockReadRate:    c.BlockReadRate,
+			BlockWriteRate:   c.BlockWriteRate,
+		}
+	}
+
+	c.history = append(c.history, sample)
+
+	if len(c.history) > historySize {
+		c.history = slices.Delete(c.history, 0, len(c.history)-historySize)
+	}
+}
+
+// resetMetrics clears the last stats of a stopped container, the next run computes its rates from zero counters.
+func (c *Container) resetMetrics() {
+	c.CPUPercentage = 0
+	c.MemoryPercentage = 0
+	c.MemoryUsage = 0
+	c.NetworkRxRate = 0
+	c.NetworkTxRate = 0
+	c.BlockReadRate = 0
+	c.BlockWriteRate = 0
+	c.PidsCurrent = 0
+	c.ThrottledPercentage = 0
+	c.ThrottledTimeRate = 0
+	c.ioCounters = ioCounters{}
+}
+
+func (c *Container) updatePids(pidsStats apiContainer.PidsStats) {
+	c.PidsCurrent = pidsStats.Current
+	c.PidsLimit = pidsStats.Limit
 }
 
-func (c *Container) SetRunningStateFromAction(action events.Action) {
-	isRunning, err := isRunningFromAction(action)
+func (c *Container) updateThrottling(stats apiContainer.StatsResponse) {
+	current := stats.CPUStats.ThrottlingData
+	previous := stats.PreCPUStats.ThrottlingData
 
-	if err != nil {
+	c.ThrottledPercentage = 0
+	c.ThrottledTimeRate = 0
+
+	// The first stats have no previous sample, their counters are the totals since the container started.
+	if stats.PreRead.IsZero() {
 		return
 	}
 
-	c.IsRunning = isRunning
+	if current.Periods > previous.Periods && current.ThrottledPeriods >= previous.ThrottledPeriods {
+		c.ThrottledPercentage = float64(current.ThrottledPeriods-previous.ThrottledPeriods) / float64(current.Periods-previous.Periods) * 100
+	}
+
+	elapsed := stats.Read.Sub(stats.PreRead)
+	if elapsed > 0 && current.ThrottledTime >= previous.ThrottledTime {
+		c.ThrottledTimeRate = float64(current.ThrottledTime-previous.ThrottledTime) / float64(elapsed)
+	}
 }
 
-func isRunningFromAction(action events.Action) (bool, error) {
-	if action == events.ActionCreate ||
-		action == events.ActionStart ||
-		action == events.ActionUnPause ||
-		action == events.ActionRestart ||
-		action == events.ActionReload {
-		return true, nil
+func (c *Container) updateIORates(stats apiContainer.StatsResponse) {
+	counters := ioCounters{read: stats.Read}
+
+	for _, network := range stats.Networks {
+		counters.networkRx += network.RxBytes
+		counters.networkTx += network.TxBytes
 	}
 
-	if action == events.ActionDie ||
-		action == events.ActionStop ||
-		action == events.ActionPause ||
-		action == events.ActionKill ||
-		action == events.ActionOOM ||
-		action == events.ActionRemove ||
-		action == events.ActionDelete ||
-		action == events.ActionDestroy {
-		return false, nil
+	// cgroup v1 reports "Read" and "Write" operations, cgroup v2 "read" and "write".
+	for _, entry := range stats.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(entry.Op) {
+		case "read":
+			counters.blockRead += entry.Value
+		case "write":
+			counters.blockWrite += entry.Value
+		}
 	}
 
-	return true, errors.New("invalid action")
+	previous := c.ioCounters
+	c.ioCounters = counters
+
+	elapsed := counters.read.Sub(previous.read).Seconds()
+	if previous.read.IsZero() || elapsed <= 0 {
+		return
+	}
+
+	c.NetworkRxRate = counterRate(counters.networkRx, previous.networkRx, elapsed)
+	c.NetworkTxRate = counterRate(counters.networkTx, previous.networkTx, elapsed)
+	c.BlockReadRate = counterRate(counters.blockRead, previous.blockRead, elapsed)
+	c.BlockWriteRate = counterRate(counters.blockWrite, previous.blockWrite, elapsed)
 }
 
-func (c *Container) Update(stats apiContainer.StatsResponse) {
-	c.updateCPUPercent(stats.CPUStats, stats.PreCPUStats)
-	c.updateMemoryPercentage(stats.MemoryStats)
+func counterRate(current uint64, previous uint64, elapsed float64) float64 {
+	// Counters are reset when the container restarts.
+	if current < previous {
+		return 0
+	}
+
+	return float64(current-previous) / elapsed
 }
 
 func (c *Container) updateMemoryPercentage(memoryStats apiContainer.MemoryStats) {
 	memUsage := c.calculateMemUsageUnixNoCache(memoryStats)
+	c.MemoryUsage = memUsage
+	c.MemoryLimit = float64(memoryStats.Limit)
 	c.MemoryPercentage = c.calculateMemPercentUnixNoCache(float64(memoryStats.Limit), memUsage)
 }
 
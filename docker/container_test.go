@@ -0,0 +1,102 @@
+package docker
+
+import (
+	"testing"
+	"time"
+
+	apiContainer "github.com/docker/docker/api/types/container"
+)
+
+func TestCounterRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  uint64
+		previous uint64
+		elapsed  float64
+		want     float64
+	}{
+		{name: "rate per second", current: 3000, previous: 1000, elapsed: 2, want: 1000},
+		{name: "no traffic", current: 1000, previous: 1000, elapsed: 2, want: 0},
+		{name: "counter reset by a restart", current: 10, previous: 1000, elapsed: 2, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := counterRate(tt.current, tt.previous, tt.elapsed); got != tt.want {
+				t.Errorf("counterRate(%d, %d, %v) = %v, want %v", tt.current, tt.previous, tt.elapsed, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateThrottling(t *testing.T) {
+	read := time.Unix(1000, 0)
+	throttling := func(periods, throttledPeriods, throttledTime uint64) apiContainer.ThrottlingData {
+		return apiContainer.ThrottlingData{Periods: periods, ThrottledPeriods: throttledPeriods, ThrottledTime: throttledTime}
+	}
+
+	tests := []struct {
+		name           string
+		current        apiContainer.ThrottlingData
+		previous       apiContainer.ThrottlingData
+		preRead        time.Time
+		wantPercentage float64
+		wantTimeRate   float64
+	}{
+		{
+			name:           "throttled",
+			current:        throttling(300, 50, uint64(time.Second)),
+			previous:       throttling(100, 0, 0),
+			preRead:        read.Add(-2 * time.Second),
+			wantPercentage: 25,
+			wantTimeRate:   0.5,
+		},
+		{
+			name:     "not throttled",
+			current:  throttling(300, 10, 1000),
+			previous: throttling(100, 10, 1000),
+			preRead:  read.Add(-2 * time.Second),
+		},
+		{
+			name:           "zero previous sample",
+			current:        throttling(10, 5, uint64(time.Second)),
+			preRead:        read.Add(-time.Second),
+			wantPercentage: 50,
+			wantTimeRate:   1,
+		},
+		{
+			name:    "first sample",
+			current: throttling(1000, 500, uint64(time.Minute)),
+		},
+		{
+			name:     "counters reset by a restart",
+			current:  throttling(10, 1, 100),
+			previous: throttling(1000, 500, uint64(time.Minute)),
+			preRead:  read.Add(-2 * time.Second),
+		},
+		{
+			name:     "no period elapsed",
+			current:  throttling(100, 10, 1000),
+			previous: throttling(100, 10, 1000),
+			preRead:  read,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Container{ThrottledPercentage: 99, ThrottledTimeRate: 99}
+
+			var stats apiContainer.StatsResponse
+			stats.Read = read
+			stats.PreRead = tt.preRead
+			stats.CPUStats.ThrottlingData = tt.current
+			stats.PreCPUStats.ThrottlingData = tt.previous
+
+			c.updateThrottling(stats)
+
+			if c.ThrottledPercentage != tt.wantPercentage || c.ThrottledTimeRate != tt.wantTimeRate {
+				t.Errorf("throttled = %v%%, %v, want %v%%, %v", c.ThrottledPercentage, c.ThrottledTimeRate, tt.wantPercentage, tt.wantTimeRate)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"maps"
 	"os"
 	"slices"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
@@ -30,13 +31,23 @@ type Docker struct {
 	client            *dockerClient.Client
 	containers        map[ContainerID]*Container
 	containersCommand chan ContainersCommand
-	requestData       <-chan tui.RequestData
-	logger            *slog.Logger
+	hostMemory        float64
+	// standaloneProjects gives every non-compose container its own project instead of grouping them.
+	standaloneProjects bool
+	// grouping and timeline are only accessed from containers commands.
+	grouping    dto.Grouping
+	timeline    []*timelineEvent
+	requestData <-chan tui.RequestData
+	logger      *slog.Logger
+
+	// resourceProjects maps networks and volumes to their compose project, it is only accessed from containers commands.
+	resourceProjects map[string]string
 }
 
 func NewDocker(
 	ctx context.Context,
 	requestData <-chan tui.RequestData,
+	standaloneProjects bool,
 	logger *slog.Logger,
 ) *Docker {
 	cli, err := dockerClient.NewClientWithOpts(dockerClient.FromEnv, dockerClient.WithAPIVersionNegotiation())
@@ -45,12 +56,23 @@ func NewDocker(
 		os.Exit(1)
 	}
 
+	// Containers without memory limit report the host memory as their limit.
+	var hostMemory float64
+	if info, err := cli.Info(ctx); err == nil {
+		hostMemory = float64(info.MemTotal)
+	} else {
+		logger.ErrorContext(ctx, "error getting docker info", slog.Any("error", err))
+	}
+
 	return &Docker{
-		client:            cli,
-		containers:        make(map[ContainerID]*Container, 256),
-		containersCommand: make(chan ContainersCommand),
-		requestData:       requestData,
-		logger:            logger,
+		client:             cli,
+		hostMemory:         hostMemory,
+		standaloneProjects: standaloneProjects,
+		containers:         make(map[ContainerID]*Container, 256),
+		resourceProjects:   make(map[string]string),
+		containersCommand:  make(chan ContainersCommand),
+		requestData:        requestData,
+		logger:             logger,
 	}
 }
 
@@ -61,6 +83,11 @@ func (d *Docker) Run(ctx context.Context) {
 		return d.handleContainersCommand(errCtx)
 	})
 
+	eg.Go(func() error {
+		d.loadResourceProjects(errCtx)
+		return nil
+	})
+
 	eg.Go(func() error {
 		d.handleEvents(errCtx)
 		return nil
@@ -75,6 +102,11 @@ func (d *Docker) Run(ctx context.Context) {
 		return nil
 	})
 
+	eg.Go(func() error {
+		d.recordHistory(errCtx)
+		return nil
+	})
+
 	if err := eg.Wait(); err != nil {
 		d.logger.ErrorContext(errCtx, "error in Docker Run", slog.Any("error", err))
 	}
@@ -94,6 +126,33 @@ func (d *Docker) handleRequests(ctx context.Context) {
 
 			case *tui.RequestProjectList:
 				d.handleRequestProjectList(r)
+
+			case *tui.RequestContainerAction:
+				go d.handleRequestContainerAction(ctx, r)
+
+			case *tui.RequestProjectAction:
+				go d.handleRequestProjectAction(ctx, r)
+
+			case *tui.RequestContainerLogs:
+				go d.handleRequestContainerLogs(r)
+
+			case *tui.RequestProjectLogs:
+				go d.handleRequestProjectLogs(r)
+
+			case *tui.RequestContainerExec:
+				go d.handleRequestContainerExec(ctx, r)
+
+			case *tui.RequestContainerInspect:
+				go d.handleRequestContainerInspect(ctx, r)
+
+			case *tui.RequestHistory:
+				d.handleRequestHistory(r)
+
+			case *tui.RequestGrouping:
+				d.handleRequestGrouping(r)
+
+			case *tui.RequestTimeline:
+				d.handleRequestTimeline(r)
 			}
 		}
 	}
@@ -105,23 +164,14 @@ func (d *Docker) handleRequestContainerProject(r *tui.RequestProject) {
 	d.containersCommand <- ContainersCommand{
 		functor: func(docker *Docker) *Container {
 			for _, c := range docker.containers {
-				if r.ProjectID == c.Project.ID {
-					response := make(chan ContainerResponse)
-					c.Command <- ContainerCommand{
-						response: response,
-					}
+				response := make(chan ContainerResponse)
+				c.Command <- ContainerCommand{
+					response: response,
+				}
 
-					container := <-response
-
-					containers = append(containers, dto.Container{
-						ID:               dto.ContainerID(container.ID),
-						Project:          container.Project,
-						Service:          container.Service,
-						Name:             container.Name,
-						CPUPercentage:    container.CPUPercentage,
-						MemoryPercentage: container.MemoryPercentage,
-						IsRunning:        container.IsRunning,
-					})
+				container := <-response
+				if project, ok := docker.containerGroup(container, r.ProjectID); ok {
+					containers = append(containers, d.containerDTO(container, project))
 				}
 			}
 
@@ -132,10 +182,99 @@ func (d *Docker) handleRequestContainerProject(r *tui.RequestProject) {
 	}
 }
 
+func (d *Docker) containerDTO(container ContainerResponse, project dto.ContainerProject) dto.Container {
+	return dto.Container{
+		ID:                  dto.ContainerID(container.ID),
+		Project:             project,
+		Service:             container.Service,
+		Name:                container.Name,
+		Image:               container.Image,
+		Labels:              container.Labels,
+		CPUPercentage:       container.CPUPercentage,
+		MemoryPercentage:    container.MemoryPercentage,
+		MemoryUsage:         container.MemoryUsage,
+		MemoryLimit:         d.memoryLimit(container.MemoryLimit),
+		NetworkRxRate:       container.NetworkRxRate,
+		NetworkTxRate:       container.NetworkTxRate,
+		BlockReadRate:       container.BlockReadRate,
+		BlockWriteRate:      container.BlockWriteRate,
+		PidsCurrent:         container.PidsCurrent,
+		PidsLimit:           container.PidsLimit,
+		ThrottledPercentage: container.ThrottledPercentage,
+		ThrottledTimeRate:   container.ThrottledTimeRate,
+		Health:              container.Health,
+		State:               container.State,
+		ExitCode:            container.ExitCode,
+		OOMKilled:           container.OOMKilled,
+		StartedAt:           container.StartedAt,
+		RestartCount:        container.RestartCount,
+		CrashLooping:        container.CrashLooping,
+	}
+}
+
+func (d *Docker) projectContainers(projectID dto.ProjectID) []ContainerResponse {
+	var containers []ContainerResponse
+
+	done := make(chan struct{})
+	d.containersCommand <- ContainersCommand{
+		functor: func(docker *Docker) *Container {
+			for _, c := range docker.containers {
+				response := make(chan ContainerResponse)
+				c.Command <- ContainerCommand{
+					response: response,
+				}
+
+				container := <-response
+				if _, ok := docker.containerGroup(container, projectID); ok {
+					containers = append(containers, container)
+				}
+			}
+
+			close(done)
+
+			return nil
+		},
+	}
+	<-done
+
+	return containers
+}
+
+func (d *Docker) containersByID(ids []dto.ContainerID) []ContainerResponse {
+	var containers []ContainerResponse
+
+	done := make(chan struct{})
+	d.containersCommand <- ContainersCommand{
+		functor: func(docker *Docker) *Container {
+			for _, id := range ids {
+				c, ok := docker.containers[ContainerID(id)]
+				if !ok {
+					continue
+				}
+
+				response := make(chan ContainerResponse)
+				c.Command <- ContainerCommand{
+					response: response,
+				}
+
+				containers = append(containers, <-response)
+			}
+
+			close(done)
+
+			return nil
+		},
+	}
+	<-done
+
+	return containers
+}
+
 func (d *Docker) handleRequestProjectList(r *tui.RequestProjectList) {
 	d.containersCommand <- ContainersCommand{
 		functor: func(docker *Docker) *Container {
 			projects := make(map[dto.ProjectID]dto.Project)
+			unlimitedProjects := make(map[dto.ProjectID]bool)
 
 			for _, c := range d.containers {
 				response := make(chan ContainerResponse)
@@ -145,34 +284,60 @@ func (d *Docker) handleRequestProjectList(r *tui.RequestProjectList) {
 
 				container := <-response
 
-				// We should copy the data to avoid data race
-				projectID := dto.ProjectID(container.Project.ID)
-
-				project, projectExist := projects[projectID]
+				// A container is aggregated in every group it belongs to.
+				for _, group := range docker.containerGroups(container) {
+					// We should copy the data to avoid data race
+					projectID := group.ID
+
+					project, projectExist := projects[projectID]
+
+					if !projectExist {
+						project = dto.Project{
+							ID:               projectID,
+							Name:             group.Name,
+							ContainersCPU:    make(map[dto.ContainerID]float64),
+							ContainersMemory: make(map[dto.ContainerID]float64),
+							ContainersState:  make(map[dto.ContainerID]dto.ContainerState),
+						}
+					}
 
-				if !projectExist {
-					project = dto.Project{
-						ID:               projectID,
-						Name:             container.Project.Name,
-						ContainersCPU:    make(map[dto.ContainerID]float64),
-						ContainersMemory: make(map[dto.ContainerID]float64),
-						ContainersState:  make(map[dto.ContainerID]bool),
+					project.CPUPercentage += container.CPUPercentage
+					project.ContainersCPU[dto.ContainerID(container.ID)] = container.CPUPercentage
+					project.MemoryPercentage += container.MemoryPercentage
+					project.ContainersMemory[dto.ContainerID(container.ID)] = container.MemoryPercentage
+					project.MemoryUsage += container.MemoryUsage
+					project.MemoryLimit += d.memoryLimit(container.MemoryLimit)
+					if d.memoryLimit(container.MemoryLimit) == 0 {
+						unlimitedProjects[projectID] = true
+					}
+					project.NetworkRxRate += container.NetworkRxRate
+					project.NetworkTxRate += container.NetworkTxRate
+					project.BlockReadRate += container.BlockReadRate
+					project.BlockWriteRate += container.BlockWriteRate
+					project.PidsCurrent += container.PidsCurrent
+					if container.ThrottledPercentage > 0 {
+						project.ContainersThrottled++
+					}
+					if container.Health == dto.HealthUnhealthy {
+						project.ContainersUnhealthy++
+					}
+					if container.CrashLooping {
+						project.ContainersCrashLooping++
 					}
-				}
 
-				project.CPUPercentage += container.CPUPercentage
-				project.ContainersCPU[dto.ContainerID(container.ID)] = container.CPUPercentage
-				project.MemoryPercentage += container.MemoryPercentage
-				project.ContainersMemory[dto.ContainerID(container.ID)] = container.MemoryPercentage
+					if container.State == dto.StateRunning {
+						project.ContainersRunning++
+					}
+					project.ContainersState[dto.ContainerID(container.ID)] = container.State
+					project.Containers = append(project.Containers, d.containerDTO(container, group))
 
-				isRunning := 0
-				if container.IsRunning {
-					isRunning = 1
+					projects[projectID] = project
 				}
+			}
 
-				project.ContainersRunning += isRunning
-				project.ContainersState[dto.ContainerID(container.ID)] = container.IsRunning
-
+			for projectID := range unlimitedProjects {
+				project := projects[projectID]
+				project.MemoryLimit = 0
 				projects[projectID] = project
 			}
 
@@ -183,6 +348,15 @@ func (d *Docker) handleRequestProjectList(r *tui.RequestProjectList) {
 	}
 }
 
+// memoryLimit returns 0 for a limit which is in fact the host memory.
+func (d *Docker) memoryLimit(limit float64) float64 {
+	if d.hostMemory > 0 && limit >= d.hostMemory {
+		return 0
+	}
+
+	return limit
+}
+
 func (d *Docker) collectContainers(ctx context.Context) error {
 	dockerContainers, err := d.client.ContainerList(ctx, apiContainer.ListOptions{All: true})
 	if err != nil {
@@ -201,16 +375,7 @@ func (d *Docker) collectContainers(ctx context.Context) error {
 }
 
 func (d *Docker) createContainer(ctx context.Context, dockerContainer apiContainer.Summary, action events.Action) {
-	projectIDraw, isProject := dockerContainer.Labels["com.docker.compose.project.working_dir"]
-
-	if !isProject {
-		return
-	}
-
-	project := dto.ContainerProject{
-		ID:   dto.ProjectID(projectIDraw),
-		Name: dockerContainer.Labels["com.docker.compose.project"],
-	}
+	project := d.containerProject(dockerContainer)
 
 	response := make(chan *Container)
 	d.containersCommand <- ContainersCommand{
@@ -237,6 +402,30 @@ func (d *Docker) createContainer(ctx context.Context, dockerContainer apiContain
 	}
 
 	go d.getContainerStatsRealtime(ctx, c)
+	go d.reconcileState(ctx, c)
+}
+
+// containerProject returns the compose project of a container, containers started without compose are
+// grouped under a synthetic standalone project, or get their own one with standaloneProjects.
+func (d *Docker) containerProject(dockerContainer apiContainer.Summary) dto.ContainerProject {
+	if workingDir, isProject := dockerContainer.Labels["com.docker.compose.project.working_dir"]; isProject {
+		return dto.ContainerProject{
+			ID:   dto.ProjectID(workingDir),
+			Name: dockerContainer.Labels["com.docker.compose.project"],
+		}
+	}
+
+	if d.standaloneProjects {
+		return dto.ContainerProject{
+			ID:   standaloneProjectID + dto.ProjectID("/"+dockerContainer.ID),
+			Name: containerName(dockerContainer),
+		}
+	}
+
+	return dto.ContainerProject{
+		ID:   standaloneProjectID,
+		Name: string(standaloneProjectID),
+	}
 }
 
 func (d *Docker) getContainerStatsRealtime(ctx context.Context, c *Container) {
@@ -274,10 +463,12 @@ func (d *Docker) getContainerStatsRealtime(ctx context.Context, c *Container) {
 		}
 
 		s := stats
-		c.Command <- ContainerCommand{
+		if !c.send(ContainerCommand{
 			functor: func(container *Container) {
 				container.Update(s)
 			},
+		}) {
+			break
 		}
 	}
 }
@@ -312,6 +503,9 @@ func (d *Docker) handleContainersCommand(ctx context.Context) error {
 func (d *Docker) handleEvents(ctx context.Context) {
 	f := filters.NewArgs()
 	f.Add("type", "container")
+	f.Add("type", "network")
+	f.Add("type", "image")
+	f.Add("type", "volume")
 	msgs, errs := d.client.Events(ctx, events.ListOptions{Filters: f})
 
 	d.logger.DebugContext(ctx, "handleEvents")
@@ -319,6 +513,17 @@ func (d *Docker) handleEvents(ctx context.Context) {
 	for {
 		select {
 		case msg := <-msgs:
+			d.recordEvent(ctx, msg)
+
+			switch msg.Type {
+			case events.NetworkEventType:
+				d.handleNetworkEvent(ctx, msg)
+				continue
+			case events.ImageEventType, events.VolumeEventType:
+				d.logger.DebugContext(ctx, "event", slog.String("type", string(msg.Type)), slog.String("action", string(msg.Action)), slog.String("id", msg.Actor.ID))
+				continue
+			}
+
 			d.logger.DebugContext(ctx, "event", slog.String("action", string(msg.Action)), slog.String("container_id", msg.Actor.ID))
 
 			response := make(chan *Container)
@@ -332,10 +537,15 @@ func (d *Docker) handleEvents(ctx context.Context) {
 			c := <-response
 
 			if c != nil {
-				c.Command <- ContainerCommand{
+				sent := c.send(ContainerCommand{
 					functor: func(container *Container) {
-						container.SetRunningStateFromAction(msg.Action)
+						container.SetStateFromEvent(msg.Action, msg.Actor.Attributes, time.Unix(0, msg.TimeNano))
+						container.SetHealthFromAction(msg.Action)
 					},
+				})
+
+				if sent && msg.Action == events.ActionDie {
+					go d.reconcileState(ctx, c)
 				}
 
 				if msg.Action == events.ActionDestroy {
@@ -356,6 +566,7 @@ func (d *Docker) handleEvents(ctx context.Context) {
 				apiContainer.Summary{
 					ID:     msg.Actor.ID,
 					Names:  []string{msg.Actor.Attributes["name"]},
+					Image:  msg.Actor.Attributes["image"],
 					Labels: msg.Actor.Attributes,
 				},
 				msg.Action,
@@ -370,3 +581,28 @@ func (d *Docker) handleEvents(ctx context.Context) {
 		}
 	}
 }
+
+func (d *Docker) handleNetworkEvent(ctx context.Context, msg events.Message) {
+	if msg.Action != events.ActionConnect && msg.Action != events.ActionDisconnect {
+		return
+	}
+
+	d.logger.DebugContext(ctx, "network event", slog.String("action", string(msg.Action)), slog.String("container_id", msg.Actor.Attributes["container"]))
+
+	d.containersCommand <- ContainersCommand{
+		functor: func(docker *Docker) *Container {
+			c := docker.containers[ContainerID(msg.Actor.Attributes["container"])]
+			if c == nil {
+				return nil
+			}
+
+			c.Command <- ContainerCommand{
+				functor: func(container *Container) {
+					container.SetNetworkFromAction(msg.Actor.Attributes["name"], msg.Action)
+				},
+			}
+
+			return nil
+		},
+	}
+}
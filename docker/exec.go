@@ -0,0 +1,127 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+
+	apiContainer "github.com/docker/docker/api/types/container"
+	"golang.org/x/term"
+
+	"github.com/syrm/c8s/tui"
+)
+
+var defaultShells = []string{"/bin/bash", "/bin/sh"}
+
+// handleRequestContainerExec takes over the terminal, the TUI must be suspended before sending the request.
+func (d *Docker) handleRequestContainerExec(ctx context.Context, r *tui.RequestContainerExec) {
+	err := d.execShell(ctx, string(r.ContainerID), r.Command)
+	if err != nil {
+		d.logger.ErrorContext(ctx, "container exec failed", slog.String("container_id", string(r.ContainerID)), slog.Any("error", err))
+	}
+
+	r.Response <- err
+}
+
+func (d *Docker) execShell(ctx context.Context, containerID string, command []string) error {
+	if len(command) == 0 {
+		command = []string{d.findShell(ctx, containerID)}
+	}
+
+	in, out, fd, closeTerminal, err := openTerminal()
+	if err != nil {
+		return err
+	}
+
+	defer closeTerminal()
+
+	width, height, err := term.GetSize(fd)
+	if err != nil {
+		return err
+	}
+
+	consoleSize := &[2]uint{uint(height), uint(width)}
+
+	exec, err := d.client.ContainerExecCreate(ctx, containerID, apiContainer.ExecOptions{
+		Tty:          true,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		ConsoleSize:  consoleSize,
+		Cmd:          command,
+	})
+	if err != nil {
+		return err
+	}
+
+	attach, err := d.client.ContainerExecAttach(ctx, exec.ID, apiContainer.ExecAttachOptions{
+		Tty:         true,
+		ConsoleSize: consoleSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	defer attach.Close()
+
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+
+	defer term.Restore(fd, state)
+
+	stopResize := d.watchResize(ctx, exec.ID, fd)
+	defer stopResize()
+
+	input, err := newTerminalInput(in, fd)
+	if err != nil {
+		return err
+	}
+
+	defer input.Close()
+
+	inputDone := make(chan struct{})
+	go func() {
+		defer close(inputDone)
+		_, _ = io.Copy(attach.Conn, input)
+	}()
+
+	_, err = io.Copy(out, attach.Reader)
+
+	// The input copy must not outlive the shell, it would steal keystrokes from the TUI.
+	input.Cancel()
+	<-inputDone
+
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	return nil
+}
+
+func (d *Docker) findShell(ctx context.Context, containerID string) string {
+	for _, shell := range defaultShells {
+		if _, err := d.client.ContainerStatPath(ctx, containerID, shell); err == nil {
+			return shell
+		}
+	}
+
+	return defaultShells[len(defaultShells)-1]
+}
+
+func (d *Docker) resizeExec(ctx context.Context, execID string, fd int) {
+	width, height, err := term.GetSize(fd)
+	if err != nil {
+		return
+	}
+
+	err = d.client.ContainerExecResize(ctx, execID, apiContainer.ResizeOptions{
+		Height: uint(height),
+		Width:  uint(width),
+	})
+	if err != nil {
+		d.logger.ErrorContext(ctx, "exec resize failed", slog.String("exec_id", execID), slog.Any("error", err))
+	}
+}
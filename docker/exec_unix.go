@@ -0,0 +1,116 @@
+//go:build !windows
+
+package docker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+func openTerminal() (in *os.File, out *os.File, fd int, closeTerminal func(), err error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return nil, nil, 0, nil, err
+	}
+
+	// Calling Fd would switch the file back to blocking mode.
+	rawConn, err := tty.SyscallConn()
+	if err != nil {
+		tty.Close()
+		return nil, nil, 0, nil, err
+	}
+
+	err = rawConn.Control(func(descriptor uintptr) {
+		fd = int(descriptor)
+	})
+	if err != nil {
+		tty.Close()
+		return nil, nil, 0, nil, err
+	}
+
+	return tty, tty, fd, func() { tty.Close() }, nil
+}
+
+func (d *Docker) watchResize(ctx context.Context, execID string, fd int) func() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGWINCH)
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-signals:
+				d.resizeExec(ctx, execID, fd)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
+
+// terminalInput reads the terminal until it is cancelled, without consuming a key once cancelled.
+// Deadlines and kqueue don't work on /dev/tty on macOS, select does.
+type terminalInput struct {
+	tty          *os.File
+	fd           int
+	cancelReader *os.File
+	cancelWriter *os.File
+}
+
+func newTerminalInput(tty *os.File, fd int) (*terminalInput, error) {
+	cancelReader, cancelWriter, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+
+	return &terminalInput{tty: tty, fd: fd, cancelReader: cancelReader, cancelWriter: cancelWriter}, nil
+}
+
+func (i *terminalInput) Read(p []byte) (int, error) {
+	cancelFd := int(i.cancelReader.Fd())
+
+	for {
+		var fds unix.FdSet
+		fds.Set(i.fd)
+		fds.Set(cancelFd)
+
+		_, err := unix.Select(max(i.fd, cancelFd)+1, &fds, nil, nil, nil)
+		if errors.Is(err, unix.EINTR) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		if fds.IsSet(cancelFd) {
+			return 0, io.EOF
+		}
+
+		n, err := i.tty.Read(p)
+		if errors.Is(err, unix.EAGAIN) {
+			continue
+		}
+
+		return n, err
+	}
+}
+
+func (i *terminalInput) Cancel() {
+	_, _ = i.cancelWriter.Write([]byte{0})
+}
+
+func (i *terminalInput) Close() {
+	i.cancelReader.Close()
+	i.cancelWriter.Close()
+}
@@ -0,0 +1,59 @@
+//go:build windows
+
+package docker
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// openTerminal uses the standard streams, which are left open once the shell exits.
+func openTerminal() (in *os.File, out *os.File, fd int, closeTerminal func(), err error) {
+	return os.Stdin, os.Stdout, int(os.Stdin.Fd()), func() {}, nil
+}
+
+func (d *Docker) watchResize(ctx context.Context, execID string, fd int) func() {
+	return func() {}
+}
+
+// terminalInput reads the console until it is cancelled, os.Stdin doesn't support deadlines.
+type terminalInput struct {
+	console windows.Handle
+	cancel  windows.Handle
+}
+
+func newTerminalInput(in *os.File, fd int) (*terminalInput, error) {
+	cancel, err := windows.CreateEvent(nil, 1, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &terminalInput{console: windows.Handle(in.Fd()), cancel: cancel}, nil
+}
+
+func (i *terminalInput) Read(p []byte) (int, error) {
+	event, err := windows.WaitForMultipleObjects([]windows.Handle{i.console, i.cancel}, false, windows.INFINITE)
+	if err != nil {
+		return 0, err
+	}
+
+	if event != windows.WAIT_OBJECT_0 {
+		return 0, io.EOF
+	}
+
+	var n uint32
+	err = windows.ReadFile(i.console, p, &n, nil)
+
+	return int(n), err
+}
+
+func (i *terminalInput) Cancel() {
+	_ = windows.SetEvent(i.cancel)
+}
+
+func (i *terminalInput) Close() {
+	_ = windows.CloseHandle(i.cancel)
+}
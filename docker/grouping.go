@@ -0,0 +1,64 @@
+package docker
+
+import (
+	"github.com/syrm/c8s/dto"
+	"github.com/syrm/c8s/tui"
+)
+
+const stackLabel = "com.docker.stack.namespace"
+
+// containerGroups must be called from a containers command, a container connected to several networks
+// belongs to several projects when grouping by network.
+func (d *Docker) containerGroups(container ContainerResponse) []dto.ContainerProject {
+	switch d.grouping.Kind {
+	case dto.GroupByStack:
+		return []dto.ContainerProject{labelGroup("stack:", container.Labels, stackLabel)}
+	case dto.GroupByLabel:
+		return []dto.ContainerProject{labelGroup("label:", container.Labels, d.grouping.Label)}
+	case dto.GroupByImage:
+		return []dto.ContainerProject{{ID: dto.ProjectID("image:" + container.Image), Name: container.Image}}
+	case dto.GroupByNetwork:
+		if len(container.Networks) == 0 {
+			return []dto.ContainerProject{{ID: "network:", Name: "no network"}}
+		}
+
+		groups := make([]dto.ContainerProject, 0, len(container.Networks))
+		for _, network := range container.Networks {
+			groups = append(groups, dto.ContainerProject{ID: dto.ProjectID("network:" + network), Name: network})
+		}
+
+		return groups
+	}
+
+	return []dto.ContainerProject{container.Project}
+}
+
+func (d *Docker) containerGroup(container ContainerResponse, projectID dto.ProjectID) (dto.ContainerProject, bool) {
+	for _, group := range d.containerGroups(container) {
+		if group.ID == projectID {
+			return group, true
+		}
+	}
+
+	return dto.ContainerProject{}, false
+}
+
+// labelGroup gathers the containers without the label in their own project.
+func labelGroup(prefix string, labels map[string]string, key string) dto.ContainerProject {
+	value, ok := labels[key]
+	if !ok {
+		return dto.ContainerProject{ID: dto.ProjectID(prefix), Name: "no " + key}
+	}
+
+	return dto.ContainerProject{ID: dto.ProjectID(prefix + key + "=" + value), Name: value}
+}
+
+func (d *Docker) handleRequestGrouping(r *tui.RequestGrouping) {
+	d.containersCommand <- ContainersCommand{
+		functor: func(docker *Docker) *Container {
+			docker.grouping = r.Grouping
+
+			return nil
+		},
+	}
+}
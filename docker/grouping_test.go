@@ -0,0 +1,101 @@
+package docker
+
+import (
+	"slices"
+	"testing"
+
+	apiContainer "github.com/docker/docker/api/types/container"
+
+	"github.com/syrm/c8s/dto"
+)
+
+func TestContainerGroups(t *testing.T) {
+	compose := dto.ContainerProject{ID: "/srv/shop", Name: "shop"}
+	container := ContainerResponse{
+		Project:  compose,
+		Image:    "nginx:1.27",
+		Networks: []string{"backend", "frontend"},
+		Labels: map[string]string{
+			stackLabel: "prod",
+			"team":     "payments",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		grouping  dto.Grouping
+		container ContainerResponse
+		want      []dto.ContainerProject
+	}{
+		{name: "compose", grouping: dto.Grouping{Kind: dto.GroupByCompose}, container: container, want: []dto.ContainerProject{compose}},
+		{name: "stack", grouping: dto.Grouping{Kind: dto.GroupByStack}, container: container, want: []dto.ContainerProject{{ID: "stack:" + stackLabel + "=prod", Name: "prod"}}},
+		{name: "without stack", grouping: dto.Grouping{Kind: dto.GroupByStack}, container: ContainerResponse{}, want: []dto.ContainerProject{{ID: "stack:", Name: "no " + stackLabel}}},
+		{name: "label", grouping: dto.Grouping{Kind: dto.GroupByLabel, Label: "team"}, container: container, want: []dto.ContainerProject{{ID: "label:team=payments", Name: "payments"}}},
+		{name: "without label", grouping: dto.Grouping{Kind: dto.GroupByLabel, Label: "owner"}, container: container, want: []dto.ContainerProject{{ID: "label:", Name: "no owner"}}},
+		{name: "empty label value", grouping: dto.Grouping{Kind: dto.GroupByLabel, Label: "team"}, container: ContainerResponse{Labels: map[string]string{"team": ""}}, want: []dto.ContainerProject{{ID: "label:team=", Name: ""}}},
+		{name: "image", grouping: dto.Grouping{Kind: dto.GroupByImage}, container: container, want: []dto.ContainerProject{{ID: "image:nginx:1.27", Name: "nginx:1.27"}}},
+		{
+			name:      "several networks",
+			grouping:  dto.Grouping{Kind: dto.GroupByNetwork},
+			container: container,
+			want:      []dto.ContainerProject{{ID: "network:backend", Name: "backend"}, {ID: "network:frontend", Name: "frontend"}},
+		},
+		{name: "no network", grouping: dto.Grouping{Kind: dto.GroupByNetwork}, container: ContainerResponse{}, want: []dto.ContainerProject{{ID: "network:", Name: "no network"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Docker{grouping: tt.grouping}
+			if got := d.containerGroups(tt.container); !slices.Equal(got, tt.want) {
+				t.Errorf("containerGroups() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainerGroup(t *testing.T) {
+	d := &Docker{grouping: dto.Grouping{Kind: dto.GroupByNetwork}}
+	container := ContainerResponse{Networks: []string{"backend", "frontend"}}
+
+	if group, ok := d.containerGroup(container, "network:frontend"); !ok || group.Name != "frontend" {
+		t.Errorf("containerGroup(network:frontend) = %v, %v, want frontend", group, ok)
+	}
+
+	if _, ok := d.containerGroup(container, "network:other"); ok {
+		t.Error("containerGroup(network:other) found a group, want none")
+	}
+}
+
+func TestContainerProject(t *testing.T) {
+	composeContainer := apiContainer.Summary{
+		ID:    "abc",
+		Names: []string{"/shop-web-1"},
+		Labels: map[string]string{
+			"com.docker.compose.project.working_dir": "/srv/shop",
+			composeProjectLabel:                      "shop",
+		},
+	}
+	standaloneContainer := apiContainer.Summary{ID: "def", Names: []string{"/redis"}}
+
+	tests := []struct {
+		name               string
+		standaloneProjects bool
+		container          apiContainer.Summary
+		want               dto.ContainerProject
+	}{
+		{name: "compose", container: composeContainer, want: dto.ContainerProject{ID: "/srv/shop", Name: "shop"}},
+		{name: "compose with standalone projects", standaloneProjects: true, container: composeContainer, want: dto.ContainerProject{ID: "/srv/shop", Name: "shop"}},
+		{name: "standalone", container: standaloneContainer, want: dto.ContainerProject{ID: standaloneProjectID, Name: "standalone"}},
+		{name: "standalone as project", standaloneProjects: true, container: standaloneContainer, want: dto.ContainerProject{ID: "standalone/def", Name: "redis"}},
+		{name: "standalone as project without name", standaloneProjects: true, container: apiContainer.Summary{ID: "ghi"}, want: dto.ContainerProject{ID: "standalone/ghi", Name: "ghi"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Docker{standaloneProjects: tt.standaloneProjects}
+			if got := d.containerProject(tt.container); got != tt.want {
+				t.Errorf("containerProject() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package docker
+
+import (
+	"strings"
+
+	"github.com/docker/docker/api/types/events"
+
+	"github.com/syrm/c8s/dto"
+)
+
+// healthFromStatus parses the health shown by the container list, like "Up 5 minutes (healthy)" or "Up 2 seconds (health: starting)".
+func healthFromStatus(status string) dto.HealthStatus {
+	switch {
+	case strings.HasSuffix(status, "(healthy)"):
+		return dto.HealthHealthy
+	case strings.HasSuffix(status, "(unhealthy)"):
+		return dto.HealthUnhealthy
+	case strings.HasSuffix(status, "(health: starting)"):
+		return dto.HealthStarting
+	}
+
+	return dto.HealthNone
+}
+
+// SetHealthFromAction clears the health of a stopped container, it probes again once restarted.
+func (c *Container) SetHealthFromAction(action events.Action) {
+	if status, ok := strings.CutPrefix(string(action), string(events.ActionHealthStatus)+": "); ok {
+		switch dto.HealthStatus(status) {
+		case dto.HealthHealthy, dto.HealthUnhealthy, dto.HealthStarting:
+			c.Health = dto.HealthStatus(status)
+			c.healthcheck = true
+		}
+
+		return
+	}
+
+	switch action {
+	case events.ActionDie:
+		c.Health = dto.HealthNone
+	case events.ActionStart, events.ActionRestart:
+		if c.healthcheck {
+			c.Health = dto.HealthStarting
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/events"
+
+	"github.com/syrm/c8s/dto"
+)
+
+func TestHealthFromStatus(t *testing.T) {
+	tests := []struct {
+		status string
+		want   dto.HealthStatus
+	}{
+		{status: "Up 5 minutes (healthy)", want: dto.HealthHealthy},
+		{status: "Up 5 minutes (unhealthy)", want: dto.HealthUnhealthy},
+		{status: "Up 3 seconds (health: starting)", want: dto.HealthStarting},
+		{status: "Up 5 minutes", want: dto.HealthNone},
+		{status: "Exited (0) 2 hours ago", want: dto.HealthNone},
+		{status: "", want: dto.HealthNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			if got := healthFromStatus(tt.status); got != tt.want {
+				t.Errorf("healthFromStatus(%q) = %q, want %q", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetHealthFromAction(t *testing.T) {
+	tests := []struct {
+		name    string
+		actions []events.Action
+		want    dto.HealthStatus
+	}{
+		{name: "health status", actions: []events.Action{"health_status: healthy"}, want: dto.HealthHealthy},
+		{name: "unknown health status", actions: []events.Action{"health_status: unknown"}, want: dto.HealthNone},
+		{name: "die clears the health", actions: []events.Action{"health_status: healthy", events.ActionDie}, want: dto.HealthNone},
+		{name: "start probes again", actions: []events.Action{"health_status: healthy", events.ActionDie, events.ActionStart}, want: dto.HealthStarting},
+		{name: "start without healthcheck", actions: []events.Action{events.ActionStart}, want: dto.HealthNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Container{Health: dto.HealthNone}
+			for _, action := range tt.actions {
+				c.SetHealthFromAction(action)
+			}
+
+			if c.Health != tt.want {
+				t.Errorf("Health = %q, want %q", c.Health, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package docker
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/syrm/c8s/dto"
+	"github.com/syrm/c8s/tui"
+)
+
+const (
+	historyInterval = 2 * time.Second
+	// historySize keeps 10 minutes of samples.
+	historySize = 300
+)
+
+// recordHistory samples every container on the same tick so their histories can be aggregated index by index.
+func (d *Docker) recordHistory(ctx context.Context) {
+	ticker := time.NewTicker(historyInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.logger.DebugContext(ctx, "recordHistory context is done")
+			return
+		case now := <-ticker.C:
+			d.containersCommand <- ContainersCommand{
+				functor: func(docker *Docker) *Container {
+					for _, c := range docker.containers {
+						c.Command <- ContainerCommand{
+							functor: func(container *Container) {
+								container.recordHistory(now)
+							},
+						}
+					}
+
+					return nil
+				},
+			}
+		}
+	}
+}
+
+func (d *Docker) handleRequestHistory(r *tui.RequestHistory) {
+	d.containersCommand <- ContainersCommand{
+		functor: func(docker *Docker) *Container {
+			history := make(dto.MetricHistory)
+
+			for _, c := range docker.containers {
+				var samples []dto.MetricSample
+				response := make(chan ContainerResponse)
+				c.Command <- ContainerCommand{
+					functor: func(container *Container) {
+						samples = slices.Clone(container.history)
+					},
+					response: response,
+				}
+				container := <-response
+
+				if _, ok := docker.containerGroup(container, r.ProjectID); r.ProjectID != "" && !ok {
+					continue
+				}
+
+				history[dto.ContainerID(c.ID)] = samples
+			}
+
+			r.Response <- history
+
+			return nil
+		},
+	}
+}
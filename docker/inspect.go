@@ -0,0 +1,126 @@
+package docker
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/syrm/c8s/dto"
+	"github.com/syrm/c8s/tui"
+)
+
+func (d *Docker) handleRequestContainerInspect(ctx context.Context, r *tui.RequestContainerInspect) {
+	detail, err := d.inspectContainer(ctx, string(r.ContainerID))
+	if err != nil {
+		d.logger.ErrorContext(ctx, "container inspect failed", slog.String("container_id", string(r.ContainerID)), slog.Any("error", err))
+	}
+
+	r.Response <- tui.ContainerInspectResponse{
+		Detail: detail,
+		Err:    err,
+	}
+}
+
+func (d *Docker) inspectContainer(ctx context.Context, containerID string) (dto.ContainerDetail, error) {
+	info, err := d.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return dto.ContainerDetail{}, err
+	}
+
+	detail := dto.ContainerDetail{
+		ID:      dto.ContainerID(info.ID),
+		Name:    info.Name,
+		ImageID: info.Image,
+		Created: parseDockerTime(info.Created),
+	}
+
+	// The digest is only known by the image, a missing image is not worth failing the whole inspect.
+	if image, err := d.client.ImageInspect(ctx, info.Image); err == nil {
+		detail.ImageDigests = image.RepoDigests
+	}
+
+	if info.Config != nil {
+		detail.Image = info.Config.Image
+		detail.Entrypoint = info.Config.Entrypoint
+		detail.Command = info.Config.Cmd
+		detail.Env = info.Config.Env
+		detail.Labels = info.Config.Labels
+	}
+
+	if info.State != nil {
+		detail.Status = string(info.State.Status)
+		detail.StartedAt = parseDockerTime(info.State.StartedAt)
+		detail.FinishedAt = parseDockerTime(info.State.FinishedAt)
+		detail.ExitCode = info.State.ExitCode
+
+		if info.State.Health != nil {
+			detail.Health = string(info.State.Health.Status)
+			detail.FailingStreak = info.State.Health.FailingStreak
+
+			for _, probe := range info.State.Health.Log {
+				detail.HealthProbes = append(detail.HealthProbes, dto.HealthProbe{
+					Start:    probe.Start,
+					End:      probe.End,
+					ExitCode: probe.ExitCode,
+					Output:   probe.Output,
+				})
+			}
+		}
+	}
+
+	if info.HostConfig != nil {
+		detail.RestartPolicy = string(info.HostConfig.RestartPolicy.Name)
+		detail.MaximumRetryCount = info.HostConfig.RestartPolicy.MaximumRetryCount
+	}
+
+	for _, mount := range info.Mounts {
+		detail.Mounts = append(detail.Mounts, dto.ContainerMount{
+			Type:        string(mount.Type),
+			Source:      mount.Source,
+			Destination: mount.Destination,
+			ReadWrite:   mount.RW,
+		})
+	}
+
+	if info.NetworkSettings != nil {
+		for _, name := range slices.Sorted(maps.Keys(info.NetworkSettings.Networks)) {
+			network := info.NetworkSettings.Networks[name]
+			detail.Networks = append(detail.Networks, dto.ContainerNetwork{
+				Name:       name,
+				IPAddress:  network.IPAddress,
+				Gateway:    network.Gateway,
+				MacAddress: network.MacAddress,
+			})
+		}
+
+		for _, port := range slices.Sorted(maps.Keys(info.NetworkSettings.Ports)) {
+			bindings := info.NetworkSettings.Ports[port]
+			if len(bindings) == 0 {
+				detail.Ports = append(detail.Ports, dto.ContainerPort{ContainerPort: string(port)})
+				continue
+			}
+
+			for _, binding := range bindings {
+				detail.Ports = append(detail.Ports, dto.ContainerPort{
+					ContainerPort: string(port),
+					HostIP:        binding.HostIP,
+					HostPort:      binding.HostPort,
+				})
+			}
+		}
+	}
+
+	return detail, nil
+}
+
+// parseDockerTime returns the zero time for empty or unset dates, Docker uses "0001-01-01T00:00:00Z" for the latter.
+func parseDockerTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return t
+}
@@ -0,0 +1,301 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	apiContainer "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+
+	"github.com/syrm/c8s/dto"
+	"github.com/syrm/c8s/tui"
+)
+
+func (d *Docker) handleRequestContainerLogs(r *tui.RequestContainerLogs) {
+	options := apiContainer.LogsOptions{Tail: r.Tail, Follow: r.Follow}
+	err := d.streamContainerLogs(r.Ctx, dto.LogLine{ContainerID: r.ContainerID}, options, r.Lines)
+	if err != nil {
+		d.logger.ErrorContext(r.Ctx, "container logs failed", slog.String("container_id", string(r.ContainerID)), slog.Any("error", err))
+	}
+
+	close(r.Lines)
+	r.Response <- err
+}
+
+// handleRequestProjectLogs reads the backlog of every container first so they can be merged in time order,
+// then follows the containers from the end of their backlog.
+func (d *Docker) handleRequestProjectLogs(r *tui.RequestProjectLogs) {
+	containers := d.projectContainers(r.ProjectID)
+	templates := make([]dto.LogLine, len(containers))
+	for index, container := range containers {
+		templates[index] = dto.LogLine{
+			ContainerID: dto.ContainerID(container.ID),
+			Service:     container.Service,
+		}
+	}
+
+	var (
+		wg     sync.WaitGroup
+		errs   []error
+		errsMu sync.Mutex
+	)
+
+	addError := func(index int, err error) {
+		d.logger.ErrorContext(r.Ctx, "container logs failed", slog.String("container_id", string(containers[index].ID)), slog.Any("error", err))
+
+		errsMu.Lock()
+		errs = append(errs, fmt.Errorf("%s: %w", containers[index].Name, err))
+		errsMu.Unlock()
+	}
+
+	start := time.Now()
+	backlogs := make([][]dto.LogLine, len(containers))
+	for index := range containers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			backlog, err := d.collectContainerLogs(r.Ctx, templates[index], apiContainer.LogsOptions{Tail: r.Tail})
+			if err != nil {
+				addError(index, err)
+			}
+
+			backlogs[index] = backlog
+		}()
+	}
+
+	wg.Wait()
+
+	if sendLogLines(r.Ctx, mergeLogLines(backlogs), r.Lines) && r.Follow {
+		merged := make(chan dto.LogLine)
+
+		for index := range containers {
+			since := start
+			if backlog := backlogs[index]; len(backlog) > 0 {
+				since = backlog[len(backlog)-1].Time.Add(time.Nanosecond)
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				options := apiContainer.LogsOptions{Follow: true, Since: fmt.Sprintf("%d.%09d", since.Unix(), since.Nanosecond())}
+				if err := d.streamContainerLogs(r.Ctx, templates[index], options, merged); err != nil {
+					addError(index, err)
+				}
+			}()
+		}
+
+		go func() {
+			wg.Wait()
+			close(merged)
+		}()
+
+		orderLogLines(r.Ctx, merged, r.Lines)
+		wg.Wait()
+	}
+
+	close(r.Lines)
+	r.Response <- errors.Join(errs...)
+}
+
+func (d *Docker) collectContainerLogs(ctx context.Context, template dto.LogLine, options apiContainer.LogsOptions) ([]dto.LogLine, error) {
+	lines := make(chan dto.LogLine)
+
+	var err error
+	go func() {
+		err = d.streamContainerLogs(ctx, template, options, lines)
+		close(lines)
+	}()
+
+	var backlog []dto.LogLine
+	for line := range lines {
+		backlog = append(backlog, line)
+	}
+
+	return backlog, err
+}
+
+func mergeLogLines(backlogs [][]dto.LogLine) []dto.LogLine {
+	var merged []dto.LogLine
+	next := make([]int, len(backlogs))
+
+	for {
+		oldest := -1
+		for index, backlog := range backlogs {
+			if next[index] == len(backlog) {
+				continue
+			}
+
+			if oldest < 0 || backlog[next[index]].Time.Before(backlogs[oldest][next[oldest]].Time) {
+				oldest = index
+			}
+		}
+
+		if oldest < 0 {
+			return merged
+		}
+
+		merged = append(merged, backlogs[oldest][next[oldest]])
+		next[oldest]++
+	}
+}
+
+func sendLogLines(ctx context.Context, lines []dto.LogLine, out chan<- dto.LogLine) bool {
+	for _, line := range lines {
+		select {
+		case out <- line:
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	return true
+}
+
+// orderLogLines forwards the live lines sorted by time, within batches small enough to keep the stream live.
+func orderLogLines(ctx context.Context, in <-chan dto.LogLine, out chan<- dto.LogLine) {
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+
+	var pending []dto.LogLine
+	flush := func() bool {
+		slices.SortStableFunc(pending, func(a, b dto.LogLine) int {
+			return a.Time.Compare(b.Time)
+		})
+
+		if !sendLogLines(ctx, pending, out) {
+			return false
+		}
+
+		pending = pending[:0]
+
+		return true
+	}
+
+	for {
+		select {
+		case line, ok := <-in:
+			if !ok {
+				flush()
+				return
+			}
+
+			pending = append(pending, line)
+		case <-ticker.C:
+			if !flush() {
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (d *Docker) streamContainerLogs(ctx context.Context, template dto.LogLine, options apiContainer.LogsOptions, lines chan<- dto.LogLine) error {
+	info, err := d.client.ContainerInspect(ctx, string(template.ContainerID))
+	if err != nil {
+		return err
+	}
+
+	options.ShowStdout = true
+	options.ShowStderr = true
+	options.Timestamps = true
+
+	body, err := d.client.ContainerLogs(ctx, string(template.ContainerID), options)
+	if err != nil {
+		return err
+	}
+
+	defer body.Close()
+
+	stdout := &logWriter{ctx: ctx, template: template, stream: dto.LogStreamStdout, lines: lines}
+	stderr := &logWriter{ctx: ctx, template: template, stream: dto.LogStreamStderr, lines: lines}
+
+	// Containers with a TTY don't multiplex their output, everything goes to stdout.
+	if info.Config != nil && info.Config.Tty {
+		_, err = io.Copy(stdout, body)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, body)
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := stdout.flush(); err != nil {
+		return err
+	}
+
+	return stderr.flush()
+}
+
+type logWriter struct {
+	ctx      context.Context
+	template dto.LogLine
+	stream   dto.LogStream
+	lines    chan<- dto.LogLine
+	buffer   []byte
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	w.buffer = append(w.buffer, p...)
+
+	for {
+		index := bytes.IndexByte(w.buffer, '\n')
+		if index < 0 {
+			return len(p), nil
+		}
+
+		line := string(w.buffer[:index])
+		w.buffer = w.buffer[index+1:]
+
+		if err := w.send(line); err != nil {
+			return 0, err
+		}
+	}
+}
+
+func (w *logWriter) flush() error {
+	if len(w.buffer) == 0 {
+		return nil
+	}
+
+	line := string(w.buffer)
+	w.buffer = nil
+
+	return w.send(line)
+}
+
+func (w *logWriter) send(raw string) error {
+	line := w.template
+	line.Stream = w.stream
+	line.Text = strings.TrimSuffix(raw, "\r")
+
+	// Docker prefixes each line with an RFC3339Nano timestamp when Timestamps is requested.
+	if prefix, text, found := strings.Cut(line.Text, " "); found {
+		if timestamp, err := time.Parse(time.RFC3339Nano, prefix); err == nil {
+			line.Time = timestamp
+			line.Text = text
+		}
+	}
+
+	select {
+	case w.lines <- line:
+		return nil
+	case <-w.ctx.Done():
+		return w.ctx.Err()
+	}
+}
@@ -0,0 +1,198 @@
+package docker
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/syrm/c8s/dto"
+)
+
+func logLine(service string, second int) dto.LogLine {
+	return dto.LogLine{Service: service, Time: time.Unix(int64(second), 0), Text: service}
+}
+
+func lineServices(lines []dto.LogLine) []string {
+	services := make([]string, len(lines))
+	for index, line := range lines {
+		services[index] = line.Service
+	}
+
+	return services
+}
+
+func TestMergeLogLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		backlogs [][]dto.LogLine
+		want     []string
+	}{
+		{name: "no backlog"},
+		{name: "empty backlogs", backlogs: [][]dto.LogLine{nil, {}}},
+		{name: "single backlog", backlogs: [][]dto.LogLine{{logLine("a", 1), logLine("b", 2)}}, want: []string{"a", "b"}},
+		{
+			name: "interleaved",
+			backlogs: [][]dto.LogLine{
+				{logLine("a1", 1), logLine("a3", 3), logLine("a5", 5)},
+				{logLine("b2", 2), logLine("b4", 4)},
+				nil,
+			},
+			want: []string{"a1", "b2", "a3", "b4", "a5"},
+		},
+		{
+			name: "same time keeps the backlog order",
+			backlogs: [][]dto.LogLine{
+				{logLine("a", 1)},
+				{logLine("b", 1)},
+			},
+			want: []string{"a", "b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lineServices(mergeLogLines(tt.backlogs)); !slices.Equal(got, tt.want) {
+				t.Errorf("mergeLogLines() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderLogLines(t *testing.T) {
+	in := make(chan dto.LogLine, 4)
+	out := make(chan dto.LogLine, 4)
+
+	for _, line := range []dto.LogLine{logLine("c", 3), logLine("a", 1), logLine("d", 3), logLine("b", 2)} {
+		in <- line
+	}
+	close(in)
+
+	orderLogLines(context.Background(), in, out)
+	close(out)
+
+	var got []dto.LogLine
+	for line := range out {
+		got = append(got, line)
+	}
+
+	if want := []string{"a", "b", "c", "d"}; !slices.Equal(lineServices(got), want) {
+		t.Errorf("orderLogLines() = %v, want %v", lineServices(got), want)
+	}
+}
+
+func TestOrderLogLinesStopsWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		orderLogLines(ctx, make(chan dto.LogLine), make(chan dto.LogLine))
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("orderLogLines() did not return once the context is done")
+	}
+}
+
+func TestLogWriter(t *testing.T) {
+	stamp := "2024-05-01T10:00:00.123456789Z"
+	at, _ := time.Parse(time.RFC3339Nano, stamp)
+
+	tests := []struct {
+		name   string
+		writes []string
+		want   []dto.LogLine
+	}{
+		{
+			name:   "timestamped line",
+			writes: []string{stamp + " hello\n"},
+			want:   []dto.LogLine{{Time: at, Text: "hello"}},
+		},
+		{
+			name:   "line split across writes",
+			writes: []string{stamp + " hel", "lo wor", "ld\n"},
+			want:   []dto.LogLine{{Time: at, Text: "hello world"}},
+		},
+		{
+			name:   "several lines in one write",
+			writes: []string{stamp + " one\n" + stamp + " two\n"},
+			want:   []dto.LogLine{{Time: at, Text: "one"}, {Time: at, Text: "two"}},
+		},
+		{
+			name:   "carriage return is trimmed",
+			writes: []string{stamp + " tty\r\n"},
+			want:   []dto.LogLine{{Time: at, Text: "tty"}},
+		},
+		{
+			name:   "without timestamp",
+			writes: []string{"plain text\n"},
+			want:   []dto.LogLine{{Text: "plain text"}},
+		},
+		{
+			name:   "last line without newline is flushed",
+			writes: []string{stamp + " first\n" + stamp + " last"},
+			want:   []dto.LogLine{{Time: at, Text: "first"}, {Time: at, Text: "last"}},
+		},
+		{
+			name:   "empty line",
+			writes: []string{"\n"},
+			want:   []dto.LogLine{{Text: ""}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := make(chan dto.LogLine, 10)
+			w := &logWriter{
+				ctx:      context.Background(),
+				template: dto.LogLine{ContainerID: "c1", Service: "web"},
+				stream:   dto.LogStreamStderr,
+				lines:    lines,
+			}
+
+			for _, write := range tt.writes {
+				if n, err := w.Write([]byte(write)); err != nil || n != len(write) {
+					t.Fatalf("Write(%q) = %d, %v", write, n, err)
+				}
+			}
+
+			if err := w.flush(); err != nil {
+				t.Fatalf("flush() = %v", err)
+			}
+			close(lines)
+
+			var got []dto.LogLine
+			for line := range lines {
+				got = append(got, line)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d lines %v, want %d", len(got), got, len(tt.want))
+			}
+
+			for index, want := range tt.want {
+				want.ContainerID = "c1"
+				want.Service = "web"
+				want.Stream = dto.LogStreamStderr
+				if !got[index].Time.Equal(want.Time) || got[index].Text != want.Text ||
+					got[index].ContainerID != want.ContainerID || got[index].Service != want.Service || got[index].Stream != want.Stream {
+					t.Errorf("line %d = %+v, want %+v", index, got[index], want)
+				}
+			}
+		})
+	}
+}
+
+func TestLogWriterStopsWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	w := &logWriter{ctx: ctx, lines: make(chan dto.LogLine)}
+	if _, err := w.Write([]byte("line\n")); err == nil {
+		t.Error("Write() = nil error, want the context error")
+	}
+}
@@ -0,0 +1,99 @@
+package docker
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strconv"
+	"time"
+
+	apiContainer "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+
+	"github.com/syrm/c8s/dto"
+)
+
+const (
+	crashLoopDies   = 3
+	crashLoopWindow = 5 * time.Minute
+)
+
+func (c *Container) SetStateFromEvent(action events.Action, attributes map[string]string, at time.Time) {
+	switch action {
+	case events.ActionCreate:
+		c.State = dto.StateCreated
+	case events.ActionStart, events.ActionRestart:
+		c.State = dto.StateRunning
+		c.ExitCode = 0
+		c.OOMKilled = false
+		c.StartedAt = at
+	case events.ActionPause:
+		c.State = dto.StatePaused
+	case events.ActionUnPause:
+		c.State = dto.StateRunning
+	case events.ActionOOM:
+		c.OOMKilled = true
+	case events.ActionDie:
+		// The restart policy may restart the container, the inspect following this event tells it.
+		c.State = dto.StateExited
+		c.resetMetrics()
+		if exitCode, err := strconv.Atoi(attributes["exitCode"]); err == nil {
+			c.ExitCode = exitCode
+		}
+
+		c.dies = append(slices.DeleteFunc(c.dies, func(die time.Time) bool {
+			return at.Sub(die) > crashLoopWindow
+		}), at)
+	case events.ActionStop:
+		c.resetMetrics()
+	case events.ActionDestroy:
+		c.State = dto.StateRemoving
+	}
+}
+
+// reconcileState reads the state from ContainerInspect, neither the list nor the events tell
+// whether a container is restarting or dead, nor whether it was killed by the OOM killer.
+func (d *Docker) reconcileState(ctx context.Context, c *Container) {
+	info, err := d.client.ContainerInspect(ctx, string(c.ID))
+	if err != nil {
+		d.logger.DebugContext(ctx, "container state reconciliation failed", slog.String("container_id", string(c.ID)), slog.Any("error", err))
+		return
+	}
+
+	if info.State == nil {
+		return
+	}
+
+	state := *info.State
+	restartCount := info.RestartCount
+	c.send(ContainerCommand{
+		functor: func(container *Container) {
+			container.setStateFromInspect(state, restartCount)
+		},
+	})
+}
+
+func (c *Container) setStateFromInspect(state apiContainer.State, restartCount int) {
+	c.State = dto.ContainerState(state.Status)
+	c.ExitCode = state.ExitCode
+	c.OOMKilled = state.OOMKilled
+	c.StartedAt = parseDockerTime(state.StartedAt)
+	c.RestartCount = restartCount
+
+	c.healthcheck = state.Health != nil
+	c.Health = dto.HealthNone
+	if state.Health != nil && state.Running {
+		c.Health = dto.HealthStatus(state.Health.Status)
+	}
+}
+
+func (c *Container) crashLooping(now time.Time) bool {
+	dies := 0
+	for _, die := range c.dies {
+		if now.Sub(die) <= crashLoopWindow {
+			dies++
+		}
+	}
+
+	return dies >= crashLoopDies
+}
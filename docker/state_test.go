@@ -0,0 +1,176 @@
+package docker
+
+import (
+	"testing"
+	"time"
+
+	apiContainer "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+
+	"github.com/syrm/c8s/dto"
+)
+
+type stateEvent struct {
+	action     events.Action
+	attributes map[string]string
+}
+
+func TestSetStateFromEvent(t *testing.T) {
+	tests := []struct {
+		name          string
+		events        []stateEvent
+		wantState     dto.ContainerState
+		wantExitCode  int
+		wantOOMKilled bool
+		wantStarted   bool
+	}{
+		{name: "create", events: []stateEvent{{action: events.ActionCreate}}, wantState: dto.StateCreated},
+		{name: "start", events: []stateEvent{{action: events.ActionCreate}, {action: events.ActionStart}}, wantState: dto.StateRunning, wantStarted: true},
+		{
+			name:         "die with exit code",
+			events:       []stateEvent{{action: events.ActionStart}, {action: events.ActionDie, attributes: map[string]string{"exitCode": "137"}}},
+			wantState:    dto.StateExited,
+			wantExitCode: 137,
+			wantStarted:  true,
+		},
+		{
+			name:          "oom then die",
+			events:        []stateEvent{{action: events.ActionStart}, {action: events.ActionOOM}, {action: events.ActionDie, attributes: map[string]string{"exitCode": "137"}}},
+			wantState:     dto.StateExited,
+			wantExitCode:  137,
+			wantOOMKilled: true,
+			wantStarted:   true,
+		},
+		{
+			name:        "die without exit code",
+			events:      []stateEvent{{action: events.ActionStart}, {action: events.ActionDie}},
+			wantState:   dto.StateExited,
+			wantStarted: true,
+		},
+		{
+			name:        "stop",
+			events:      []stateEvent{{action: events.ActionStart}, {action: events.ActionKill}, {action: events.ActionDie, attributes: map[string]string{"exitCode": "0"}}, {action: events.ActionStop}},
+			wantState:   dto.StateExited,
+			wantStarted: true,
+		},
+		{
+			name:        "restart clears the last exit",
+			events:      []stateEvent{{action: events.ActionOOM}, {action: events.ActionDie, attributes: map[string]string{"exitCode": "1"}}, {action: events.ActionRestart}},
+			wantState:   dto.StateRunning,
+			wantStarted: true,
+		},
+		{name: "pause", events: []stateEvent{{action: events.ActionStart}, {action: events.ActionPause}}, wantState: dto.StatePaused, wantStarted: true},
+		{name: "unpause", events: []stateEvent{{action: events.ActionStart}, {action: events.ActionPause}, {action: events.ActionUnPause}}, wantState: dto.StateRunning, wantStarted: true},
+		{name: "destroy", events: []stateEvent{{action: events.ActionDie}, {action: events.ActionDestroy}}, wantState: dto.StateRemoving},
+		{name: "exec events are ignored", events: []stateEvent{{action: events.ActionStart}, {action: events.ActionExecStart}}, wantState: dto.StateRunning, wantStarted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Container{}
+			at := time.Unix(1000, 0)
+			for _, event := range tt.events {
+				c.SetStateFromEvent(event.action, event.attributes, at)
+			}
+
+			if c.State != tt.wantState || c.ExitCode != tt.wantExitCode || c.OOMKilled != tt.wantOOMKilled || c.StartedAt.Equal(at) != tt.wantStarted {
+				t.Errorf("state = %q, exit code %d, OOM killed %v, started at %v, want %q, %d, %v, started %v",
+					c.State, c.ExitCode, c.OOMKilled, c.StartedAt, tt.wantState, tt.wantExitCode, tt.wantOOMKilled, tt.wantStarted)
+			}
+		})
+	}
+}
+
+func TestSetStateFromEventResetsMetrics(t *testing.T) {
+	for _, action := range []events.Action{events.ActionDie, events.ActionStop} {
+		t.Run(string(action), func(t *testing.T) {
+			c := &Container{CPUPercentage: 50, NetworkRxRate: 10, ioCounters: ioCounters{networkRx: 100, read: time.Now()}}
+			c.SetStateFromEvent(action, nil, time.Now())
+
+			if c.CPUPercentage != 0 || c.NetworkRxRate != 0 || c.ioCounters != (ioCounters{}) {
+				t.Errorf("metrics after %s = %v, %v, %+v, want them reset", action, c.CPUPercentage, c.NetworkRxRate, c.ioCounters)
+			}
+		})
+	}
+}
+
+func TestSetStateFromInspect(t *testing.T) {
+	tests := []struct {
+		name       string
+		state      apiContainer.State
+		wantState  dto.ContainerState
+		wantHealth dto.HealthStatus
+	}{
+		{name: "restarting", state: apiContainer.State{Status: "restarting", Restarting: true, ExitCode: 1}, wantState: dto.StateRestarting, wantHealth: dto.HealthNone},
+		{name: "dead", state: apiContainer.State{Status: "dead", Dead: true}, wantState: dto.StateDead, wantHealth: dto.HealthNone},
+		{
+			name:       "running with healthcheck",
+			state:      apiContainer.State{Status: "running", Running: true, Health: &apiContainer.Health{Status: "healthy"}},
+			wantState:  dto.StateRunning,
+			wantHealth: dto.HealthHealthy,
+		},
+		{
+			name:       "exited keeps no stale health",
+			state:      apiContainer.State{Status: "exited", Health: &apiContainer.Health{Status: "healthy"}},
+			wantState:  dto.StateExited,
+			wantHealth: dto.HealthNone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Container{}
+			c.setStateFromInspect(tt.state, 4)
+
+			if c.State != tt.wantState || c.Health != tt.wantHealth || c.RestartCount != 4 || c.ExitCode != tt.state.ExitCode {
+				t.Errorf("state = %q, health %q, restarts %d, exit code %d, want %q, %q, 4, %d",
+					c.State, c.Health, c.RestartCount, c.ExitCode, tt.wantState, tt.wantHealth, tt.state.ExitCode)
+			}
+		})
+	}
+}
+
+func TestCrashLooping(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name string
+		dies []time.Duration
+		want bool
+	}{
+		{name: "never died"},
+		{name: "below the threshold", dies: []time.Duration{-2 * time.Minute, -time.Minute}},
+		{name: "dies within the window", dies: []time.Duration{-4 * time.Minute, -2 * time.Minute, -time.Minute}, want: true},
+		{name: "oldest die out of the window", dies: []time.Duration{-6 * time.Minute, -2 * time.Minute, -time.Minute}},
+		{name: "die at the window limit", dies: []time.Duration{-crashLoopWindow, -2 * time.Minute, -time.Minute}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Container{}
+			for _, die := range tt.dies {
+				c.SetStateFromEvent(events.ActionDie, map[string]string{"exitCode": "1"}, now.Add(die))
+			}
+
+			if got := c.crashLooping(now); got != tt.want {
+				t.Errorf("crashLooping() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCrashLoopingRecovers(t *testing.T) {
+	now := time.Now()
+	c := &Container{}
+	for _, die := range []time.Duration{-3 * time.Minute, -2 * time.Minute, -time.Minute} {
+		c.SetStateFromEvent(events.ActionDie, nil, now.Add(die))
+	}
+
+	if !c.crashLooping(now) {
+		t.Fatal("crashLooping() = false, want true")
+	}
+
+	if c.crashLooping(now.Add(crashLoopWindow)) {
+		t.Error("crashLooping() once the dies are out of the window = true, want false")
+	}
+}
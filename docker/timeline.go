@@ -0,0 +1,355 @@
+package docker
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	apiContainer "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+
+	"github.com/syrm/c8s/dto"
+	"github.com/syrm/c8s/tui"
+)
+
+const timelineSize = 1000
+
+const composeProjectLabel = "com.docker.compose.project"
+
+var timelineActions = map[events.Type][]events.Action{
+	events.ContainerEventType: {
+		events.ActionCreate,
+		events.ActionStart,
+		events.ActionRestart,
+		events.ActionDie,
+		events.ActionOOM,
+		events.ActionKill,
+		events.ActionStop,
+		events.ActionPause,
+		events.ActionUnPause,
+		events.ActionDestroy,
+	},
+	events.ImageEventType:   {events.ActionPull, events.ActionDelete, events.ActionTag, events.ActionUnTag},
+	events.NetworkEventType: {events.ActionCreate, events.ActionConnect, events.ActionDisconnect, events.ActionDestroy},
+	events.VolumeEventType:  {events.ActionCreate, events.ActionMount, events.ActionUnmount, events.ActionDestroy},
+}
+
+var timelineAttributes = []string{"image", "exitCode", "signal", "execDuration", "type", "driver", "destination", "read/write"}
+
+// timelineEvent keeps the containers the event is about, so the timeline follows the grouping even for removed containers.
+// The containers of an image are also matched when the timeline is requested, a pull comes before them.
+type timelineEvent struct {
+	event      dto.Event
+	containers []ContainerResponse
+	image      string
+}
+
+// recordEvent must be called before the event is applied, a destroyed container is still known then.
+func (d *Docker) recordEvent(ctx context.Context, msg events.Message) {
+	if !slices.Contains(timelineActions[msg.Type], msg.Action) &&
+		!(msg.Type == events.ContainerEventType && strings.HasPrefix(string(msg.Action), string(events.ActionHealthStatus))) {
+		return
+	}
+
+	event := dto.Event{
+		Time:       time.Unix(0, msg.TimeNano),
+		Type:       string(msg.Type),
+		Name:       eventName(msg),
+		Action:     string(msg.Action),
+		Attributes: make(map[string]string),
+	}
+
+	for _, name := range timelineAttributes {
+		if value, ok := msg.Actor.Attributes[name]; ok {
+			event.Attributes[name] = value
+		}
+	}
+
+	d.containersCommand <- ContainersCommand{
+		functor: func(docker *Docker) *Container {
+			e := &timelineEvent{event: event}
+			if msg.Type == events.ImageEventType {
+				e.image = eventName(msg)
+			}
+
+			key := resourceKey(msg)
+			composeProject, known := docker.resourceProjects[key]
+			if msg.Action == events.ActionDestroy {
+				delete(docker.resourceProjects, key)
+			}
+
+			e.setContainers(docker.eventContainers(msg, composeProject))
+			docker.recordTimeline(e)
+
+			if !known && key != "" && msg.Action != events.ActionDestroy {
+				go docker.resolveResourceProject(ctx, msg, e)
+			}
+
+			return nil
+		},
+	}
+}
+
+func resourceKey(msg events.Message) string {
+	if msg.Type != events.NetworkEventType && msg.Type != events.VolumeEventType {
+		return ""
+	}
+
+	return string(msg.Type) + "/" + msg.Actor.ID
+}
+
+// resolveResourceProject inspects the network or volume out of the events loop, its events don't carry labels.
+func (d *Docker) resolveResourceProject(ctx context.Context, msg events.Message, e *timelineEvent) {
+	composeProject, err := d.resourceComposeProject(ctx, msg.Type, msg.Actor.ID)
+	if err != nil {
+		d.logger.DebugContext(ctx, "event labels unavailable", slog.String("type", string(msg.Type)), slog.String("id", msg.Actor.ID), slog.Any("error", err))
+		return
+	}
+
+	d.containersCommand <- ContainersCommand{
+		functor: func(docker *Docker) *Container {
+			docker.resourceProjects[resourceKey(msg)] = composeProject
+
+			if composeProject != "" && len(e.containers) == 0 {
+				e.setContainers(docker.eventContainers(msg, composeProject))
+			}
+
+			return nil
+		},
+	}
+}
+
+func eventName(msg events.Message) string {
+	switch msg.Type {
+	case events.ContainerEventType:
+		return strings.TrimPrefix(msg.Actor.Attributes["name"], "/")
+	case events.VolumeEventType:
+		return msg.Actor.ID
+	}
+
+	if name := msg.Actor.Attributes["name"]; name != "" {
+		return name
+	}
+
+	return msg.Actor.ID
+}
+
+func (d *Docker) resourceComposeProject(ctx context.Context, eventType events.Type, id string) (string, error) {
+	if eventType == events.NetworkEventType {
+		info, err := d.client.NetworkInspect(ctx, id, network.InspectOptions{})
+		return info.Labels[composeProjectLabel], err
+	}
+
+	info, err := d.client.VolumeInspect(ctx, id)
+
+	return info.Labels[composeProjectLabel], err
+}
+
+// loadResourceProjects maps the compose networks and volumes created before c8s started, so their destroy events are routed.
+func (d *Docker) loadResourceProjects(ctx context.Context) {
+	f := filters.NewArgs(filters.Arg("label", composeProjectLabel))
+	projects := make(map[string]string)
+
+	networks, err := d.client.NetworkList(ctx, network.ListOptions{Filters: f})
+	if err != nil {
+		d.logger.ErrorContext(ctx, "error listing networks", slog.Any("error", err))
+	}
+
+	for _, n := range networks {
+		projects[string(events.NetworkEventType)+"/"+n.ID] = n.Labels[composeProjectLabel]
+	}
+
+	volumes, err := d.client.VolumeList(ctx, volume.ListOptions{Filters: f})
+	if err != nil {
+		d.logger.ErrorContext(ctx, "error listing volumes", slog.Any("error", err))
+	}
+
+	for _, v := range volumes.Volumes {
+		projects[string(events.VolumeEventType)+"/"+v.Name] = v.Labels[composeProjectLabel]
+	}
+
+	d.containersCommand <- ContainersCommand{
+		functor: func(docker *Docker) *Container {
+			for key, composeProject := range projects {
+				if _, known := docker.resourceProjects[key]; !known {
+					docker.resourceProjects[key] = composeProject
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+// eventContainers must be called from a containers command.
+func (d *Docker) eventContainers(msg events.Message, composeProject string) []ContainerResponse {
+	if msg.Type == events.ContainerEventType {
+		return []ContainerResponse{d.eventContainer(msg)}
+	}
+
+	var containers []ContainerResponse
+	for id, c := range d.containers {
+		switch {
+		case msg.Actor.Attributes["container"] != "":
+			if string(id) != msg.Actor.Attributes["container"] {
+				continue
+			}
+		case msg.Type == events.ImageEventType:
+			if !sameImage(c.Image, eventName(msg)) && c.Image != msg.Actor.ID {
+				continue
+			}
+		case composeProject != "":
+			if c.Labels[composeProjectLabel] != composeProject {
+				continue
+			}
+		default:
+			continue
+		}
+
+		response := make(chan ContainerResponse)
+		c.Command <- ContainerCommand{response: response}
+		containers = append(containers, <-response)
+	}
+
+	return containers
+}
+
+// sameImage compares image references, the "latest" tag being implicit.
+func sameImage(a string, b string) bool {
+	withTag := func(reference string) string {
+		if strings.Contains(reference[strings.LastIndex(reference, "/")+1:], ":") {
+			return reference
+		}
+
+		return reference + ":latest"
+	}
+
+	return a != "" && withTag(a) == withTag(b)
+}
+
+// eventContainer builds the container from the event attributes when it is not known yet.
+func (d *Docker) eventContainer(msg events.Message) ContainerResponse {
+	if c, ok := d.containers[ContainerID(msg.Actor.ID)]; ok {
+		response := make(chan ContainerResponse)
+		c.Command <- ContainerCommand{response: response}
+
+		return <-response
+	}
+
+	summary := apiContainer.Summary{
+		ID:     msg.Actor.ID,
+		Names:  []string{msg.Actor.Attributes["name"]},
+		Image:  msg.Actor.Attributes["image"],
+		Labels: msg.Actor.Attributes,
+	}
+
+	return ContainerResponse{
+		ID:      ContainerID(summary.ID),
+		Project: d.containerProject(summary),
+		Service: containerService(summary),
+		Name:    containerName(summary),
+		Image:   summary.Image,
+		Labels:  summary.Labels,
+	}
+}
+
+func (e *timelineEvent) setContainers(containers []ContainerResponse) {
+	e.containers = containers
+	e.event.Projects = eventProjects(containers)
+}
+
+func eventProjects(containers []ContainerResponse) []dto.ContainerProject {
+	var projects []dto.ContainerProject
+	for _, container := range containers {
+		if !slices.Contains(projects, container.Project) {
+			projects = append(projects, container.Project)
+		}
+	}
+
+	return projects
+}
+
+// recordTimeline must be called from a containers command.
+func (d *Docker) recordTimeline(e *timelineEvent) {
+	d.timeline = append(d.timeline, e)
+	if len(d.timeline) > timelineSize {
+		d.timeline = d.timeline[len(d.timeline)-timelineSize:]
+	}
+}
+
+func (d *Docker) handleRequestTimeline(r *tui.RequestTimeline) {
+	d.containersCommand <- ContainersCommand{
+		functor: func(docker *Docker) *Container {
+			var (
+				timeline []dto.Event
+				current  []ContainerResponse
+			)
+
+			for _, e := range docker.timeline {
+				event, containers := e.event, e.containers
+				if e.image != "" {
+					if current == nil {
+						current = docker.snapshotContainers()
+					}
+
+					containers = imageContainers(containers, current, e.image)
+					event.Projects = eventProjects(containers)
+				}
+
+				if r.ProjectID == "" && r.ContainerID == "" || slices.ContainsFunc(containers, func(container ContainerResponse) bool {
+					return docker.timelineMatches(container, r)
+				}) {
+					timeline = append(timeline, event)
+				}
+			}
+
+			r.Response <- timeline
+
+			return nil
+		},
+	}
+}
+
+// snapshotContainers must be called from a containers command.
+func (d *Docker) snapshotContainers() []ContainerResponse {
+	containers := make([]ContainerResponse, 0, len(d.containers))
+	for _, c := range d.containers {
+		response := make(chan ContainerResponse)
+		c.Command <- ContainerCommand{response: response}
+		containers = append(containers, <-response)
+	}
+
+	return containers
+}
+
+func imageContainers(recorded []ContainerResponse, current []ContainerResponse, image string) []ContainerResponse {
+	containers := slices.Clone(recorded)
+	for _, container := range current {
+		if sameImage(container.Image, image) && !slices.ContainsFunc(containers, func(c ContainerResponse) bool {
+			return c.ID == container.ID
+		}) {
+			containers = append(containers, container)
+		}
+	}
+
+	return containers
+}
+
+func (d *Docker) timelineMatches(container ContainerResponse, r *tui.RequestTimeline) bool {
+	if r.ContainerID != "" && dto.ContainerID(container.ID) != r.ContainerID {
+		return false
+	}
+
+	if r.ProjectID == "" {
+		return true
+	}
+
+	_, ok := d.containerGroup(container, r.ProjectID)
+
+	return ok
+}
@@ -0,0 +1,48 @@
+package docker
+
+import "testing"
+
+func TestSameImage(t *testing.T) {
+	tests := []struct {
+		a    string
+		b    string
+		want bool
+	}{
+		{a: "nginx", b: "nginx", want: true},
+		{a: "nginx", b: "nginx:latest", want: true},
+		{a: "nginx:latest", b: "nginx", want: true},
+		{a: "nginx:1.27", b: "nginx", want: false},
+		{a: "nginx:1.27", b: "nginx:1.27", want: true},
+		{a: "registry:5000/app", b: "registry:5000/app:latest", want: true},
+		{a: "registry:5000/app", b: "registry:5000/app:v2", want: false},
+		{a: "app", b: "other/app", want: false},
+		{a: "", b: "", want: false},
+		{a: "", b: "nginx", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			if got := sameImage(tt.a, tt.b); got != tt.want {
+				t.Errorf("sameImage(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImageContainers(t *testing.T) {
+	recorded := []ContainerResponse{{ID: "1", Image: "nginx"}}
+	current := []ContainerResponse{
+		{ID: "1", Image: "nginx"},
+		{ID: "2", Image: "nginx:latest"},
+		{ID: "3", Image: "redis"},
+	}
+
+	got := imageContainers(recorded, current, "nginx")
+	if len(got) != 2 || got[0].ID != "1" || got[1].ID != "2" {
+		t.Errorf("imageContainers() = %v, want the containers 1 and 2", got)
+	}
+
+	if len(recorded) != 1 {
+		t.Errorf("imageContainers() changed the recorded containers to %v", recorded)
+	}
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type ContainerID string
 
 type ContainerDeletable interface {
@@ -11,9 +13,32 @@ type Container struct {
 	Project          ContainerProject
 	Service          string
 	Name             string
+	Image            string
+	Labels           map[string]string
 	CPUPercentage    float64
 	MemoryPercentage float64
-	IsRunning        bool
+	MemoryUsage      float64
+	// MemoryLimit is 0 when the container has no memory limit.
+	MemoryLimit    float64
+	NetworkRxRate  float64
+	NetworkTxRate  float64
+	BlockReadRate  float64
+	BlockWriteRate float64
+	PidsCurrent    uint64
+	// PidsLimit is 0 when the container has no pids limit.
+	PidsLimit uint64
+	// ThrottledPercentage is the share of CPU periods where the container hit its quota.
+	ThrottledPercentage float64
+	// ThrottledTimeRate is the time spent throttled per second.
+	ThrottledTimeRate float64
+	Health            HealthStatus
+	State             ContainerState
+	// ExitCode and OOMKilled are the ones of the last exit.
+	ExitCode     int
+	OOMKilled    bool
+	StartedAt    time.Time
+	RestartCount int
+	CrashLooping bool
 }
 
 func (c Container) Deleted() bool {
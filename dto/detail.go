@@ -0,0 +1,49 @@
+package dto
+
+import "time"
+
+type ContainerDetail struct {
+	ID                ContainerID
+	Name              string
+	Image             string
+	ImageID           string
+	ImageDigests      []string
+	Entrypoint        []string
+	Command           []string
+	Env               []string
+	Labels            map[string]string
+	Mounts            []ContainerMount
+	Networks          []ContainerNetwork
+	Ports             []ContainerPort
+	RestartPolicy     string
+	MaximumRetryCount int
+	Status            string
+	Health            string
+	FailingStreak     int
+	// HealthProbes are the last healthcheck results, oldest first.
+	HealthProbes []HealthProbe
+	Created      time.Time
+	StartedAt    time.Time
+	FinishedAt   time.Time
+	ExitCode     int
+}
+
+type ContainerMount struct {
+	Type        string
+	Source      string
+	Destination string
+	ReadWrite   bool
+}
+
+type ContainerNetwork struct {
+	Name       string
+	IPAddress  string
+	Gateway    string
+	MacAddress string
+}
+
+type ContainerPort struct {
+	ContainerPort string
+	HostIP        string
+	HostPort      string
+}
@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+type Event struct {
+	Time time.Time
+	Type string
+	Name string
+	// Projects are the projects of the containers the event is about, an image may be used by several.
+	Projects []ContainerProject
+	Action   string
+	// Attributes are the meaningful attributes of the event, the labels are left out.
+	Attributes map[string]string
+}
@@ -0,0 +1,34 @@
+package dto
+
+type GroupingKind int
+
+const (
+	GroupByCompose GroupingKind = iota
+	GroupByStack
+	GroupByLabel
+	GroupByImage
+	GroupByNetwork
+)
+
+type Grouping struct {
+	Kind GroupingKind
+	// Label is the label key used by GroupByLabel.
+	Label string
+}
+
+func (k GroupingKind) String() string {
+	switch k {
+	case GroupByCompose:
+		return "compose project"
+	case GroupByStack:
+		return "stack"
+	case GroupByLabel:
+		return "label"
+	case GroupByImage:
+		return "image"
+	case GroupByNetwork:
+		return "network"
+	}
+
+	return "unknown"
+}
@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+type HealthStatus string
+
+const (
+	HealthNone      HealthStatus = "none"
+	HealthStarting  HealthStatus = "starting"
+	HealthHealthy   HealthStatus = "healthy"
+	HealthUnhealthy HealthStatus = "unhealthy"
+)
+
+type HealthProbe struct {
+	Start    time.Time
+	End      time.Time
+	ExitCode int
+	Output   string
+}
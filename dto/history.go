@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+type MetricSample struct {
+	Time             time.Time
+	CPUPercentage    float64
+	MemoryPercentage float64
+	MemoryUsage      float64
+	NetworkRxRate    float64
+	NetworkTxRate    float64
+	BlockReadRate    float64
+	BlockWriteRate   float64
+}
+
+// MetricHistory holds samples from the oldest to the newest, every container being sampled on the same ticks.
+type MetricHistory map[ContainerID][]MetricSample
@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+type LogStream int
+
+const (
+	LogStreamStdout LogStream = iota
+	LogStreamStderr
+)
+
+type LogLine struct {
+	ContainerID ContainerID
+	Service     string
+	Stream      LogStream
+	Time        time.Time
+	Text        string
+}
@@ -3,12 +3,25 @@ package dto
 type ProjectID string
 
 type Project struct {
-	ID                ProjectID
-	Name              string
-	CPUPercentage     float64
-	MemoryPercentage  float64
-	ContainersRunning int
-	ContainersCPU     map[ContainerID]float64
-	ContainersMemory  map[ContainerID]float64
-	ContainersState   map[ContainerID]bool
+	ID               ProjectID
+	Name             string
+	CPUPercentage    float64
+	MemoryPercentage float64
+	MemoryUsage      float64
+	// MemoryLimit is 0 as soon as one container has no memory limit.
+	MemoryLimit    float64
+	NetworkRxRate  float64
+	NetworkTxRate  float64
+	BlockReadRate  float64
+	BlockWriteRate float64
+	PidsCurrent    uint64
+	// ContainersThrottled counts the containers which hit their CPU quota on the last stats.
+	ContainersThrottled    int
+	ContainersUnhealthy    int
+	ContainersCrashLooping int
+	ContainersRunning      int
+	ContainersCPU          map[ContainerID]float64
+	ContainersMemory       map[ContainerID]float64
+	ContainersState        map[ContainerID]ContainerState
+	Containers             []Container
 }
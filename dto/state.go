@@ -0,0 +1,13 @@
+package dto
+
+type ContainerState string
+
+const (
+	StateCreated    ContainerState = "created"
+	StateRunning    ContainerState = "running"
+	StatePaused     ContainerState = "paused"
+	StateRestarting ContainerState = "restarting"
+	StateExited     ContainerState = "exited"
+	StateDead       ContainerState = "dead"
+	StateRemoving   ContainerState = "removing"
+)
@@ -2,14 +2,20 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log/slog"
 	"os"
+	"strings"
 
 	"github.com/syrm/c8s/docker"
 	"github.com/syrm/c8s/tui"
 )
 
 func main() {
+	execCommand := flag.String("exec", "", "command run by the exec key, defaults to /bin/bash then /bin/sh")
+	standaloneProjects := flag.Bool("standalone-projects", false, "show each container started without compose as its own project instead of grouping them under \"standalone\"")
+	flag.Parse()
+
 	ctx := context.Background()
 
 	file, err := os.OpenFile("app.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o666)
@@ -23,9 +29,9 @@ func main() {
 	// ctx2, cancel := context.WithTimeout(ctx, 10 * time.Second)
 	// defer cancel()
 
-	t := tui.NewTui(logger)
+	t := tui.NewTui(logger, strings.Fields(*execCommand))
 
-	doc := docker.NewDocker(ctx, t.GetRequestData(), logger)
+	doc := docker.NewDocker(ctx, t.GetRequestData(), *standaloneProjects, logger)
 	go doc.Run(ctx)
 
 	t.Render(ctx)
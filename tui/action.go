@@ -0,0 +1,272 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"github.com/syrm/c8s/dto"
+)
+
+const (
+	pageMain    = "main"
+	pageConfirm = "confirm"
+	pageHealth  = "health"
+)
+
+const confirmMaxNames = 15
+
+type ContainerAction int
+
+const (
+	ContainerActionStart ContainerAction = iota
+	ContainerActionStop
+	ContainerActionRestart
+	ContainerActionPause
+	ContainerActionUnpause
+	ContainerActionKill
+	ContainerActionRemove
+)
+
+var containerActionKeys = map[rune]ContainerAction{
+	's': ContainerActionStart,
+	'x': ContainerActionStop,
+	'r': ContainerActionRestart,
+	'p': ContainerActionPause,
+	'u': ContainerActionUnpause,
+	'K': ContainerActionKill,
+	'D': ContainerActionRemove,
+}
+
+// projectActionKeys apply to every container of the selected project, "D" being a compose down.
+var projectActionKeys = map[rune]ContainerAction{
+	's': ContainerActionStart,
+	'x': ContainerActionStop,
+	'r': ContainerActionRestart,
+	'D': ContainerActionRemove,
+}
+
+func (a ContainerAction) String() string {
+	switch a {
+	case ContainerActionStart:
+		return "start"
+	case ContainerActionStop:
+		return "stop"
+	case ContainerActionRestart:
+		return "restart"
+	case ContainerActionPause:
+		return "pause"
+	case ContainerActionUnpause:
+		return "unpause"
+	case ContainerActionKill:
+		return "kill"
+	case ContainerActionRemove:
+		return "remove"
+	}
+
+	return "unknown"
+}
+
+func (a ContainerAction) Destructive() bool {
+	return a == ContainerActionStop || a == ContainerActionKill || a == ContainerActionRemove
+}
+
+func (t *Tui) selectedContainer() (dto.Container, bool) {
+	if t.viewMode == viewModeTree {
+		return t.selectedTreeContainer()
+	}
+
+	table, _ := t.containerTable()
+	containerID, ok := selectedReference[dto.ContainerID](table)
+	if !ok {
+		return dto.Container{}, false
+	}
+
+	if t.viewMode == viewModeContainers {
+		container, ok := t.allContainers[containerID]
+		return container, ok
+	}
+
+	t.tableContainerDataLock.RLock()
+	defer t.tableContainerDataLock.RUnlock()
+
+	container, ok := t.tableContainerData[containerID]
+
+	return container, ok
+}
+
+func (t *Tui) runContainerAction(action ContainerAction) {
+	container, ok := t.selectedContainer()
+	if !ok {
+		return
+	}
+
+	if !action.Destructive() {
+		go t.sendContainerAction(container, action)
+		return
+	}
+
+	t.confirm(
+		fmt.Sprintf("%s container %s?", action, tview.Escape(container.Name)),
+		action.String(),
+		func() {
+			go t.sendContainerAction(container, action)
+		},
+	)
+}
+
+func (t *Tui) sendContainerAction(container dto.Container, action ContainerAction) {
+	t.setStatus(fmt.Sprintf("%s %s…", action, tview.Escape(container.Name)))
+
+	response := make(chan error)
+	t.requestData <- &RequestContainerAction{
+		ContainerID: container.ID,
+		Action:      action,
+		Response:    response,
+	}
+
+	if err := <-response; err != nil {
+		t.setStatus(fmt.Sprintf("[red]%s %s failed: %s", action, tview.Escape(container.Name), tview.Escape(err.Error())))
+		return
+	}
+
+	t.setStatus(fmt.Sprintf("[green]%s %s done", action, tview.Escape(container.Name)))
+}
+
+func (t *Tui) selectedProject() (dto.Project, bool) {
+	table, _ := t.projectTable()
+	projectID, ok := selectedReference[dto.ProjectID](table)
+	if !ok {
+		return dto.Project{}, false
+	}
+
+	t.tableProjectDataLock.RLock()
+	defer t.tableProjectDataLock.RUnlock()
+
+	project, ok := t.tableProjectData[projectID]
+
+	return project, ok
+}
+
+func (t *Tui) runProjectAction(action ContainerAction) {
+	project, ok := t.selectedProject()
+	if !ok {
+		return
+	}
+
+	if !action.Destructive() {
+		go t.sendProjectAction(project, action)
+		return
+	}
+
+	// Groups other than compose projects gather containers of unrelated projects, they are all listed.
+	names := make([]string, 0, len(project.Containers))
+	for _, container := range project.Containers {
+		names = append(names, tview.Escape(container.Name))
+	}
+	slices.Sort(names)
+
+	if len(names) > confirmMaxNames {
+		names = append(names[:confirmMaxNames], fmt.Sprintf("and %d more", len(names)-confirmMaxNames))
+	}
+
+	t.confirm(
+		fmt.Sprintf("%s the %d containers of %s?\n\n%s", action, len(project.Containers), tview.Escape(project.Name), strings.Join(names, "\n")),
+		action.String(),
+		func() {
+			go t.sendProjectAction(project, action)
+		},
+	)
+}
+
+func (t *Tui) sendProjectAction(project dto.Project, action ContainerAction) {
+	t.setStatus(fmt.Sprintf("%s %s…", action, tview.Escape(project.Name)))
+
+	progress := make(chan ProjectActionProgress)
+	response := make(chan error)
+	containerIDs := make([]dto.ContainerID, 0, len(project.Containers))
+	for _, container := range project.Containers {
+		containerIDs = append(containerIDs, container.ID)
+	}
+
+	t.requestData <- &RequestProjectAction{
+		ProjectID:    project.ID,
+		ContainerIDs: containerIDs,
+		Action:       action,
+		Progress:     progress,
+		Response:     response,
+	}
+
+	done := 0
+	for p := range progress {
+		done++
+
+		result := "[green]ok[-]"
+		if p.Err != nil {
+			result = "[red]failed[-]"
+		}
+
+		t.setStatus(fmt.Sprintf("%s %s %d/%d: %s %s", action, tview.Escape(project.Name), done, p.Total, tview.Escape(p.ContainerName), result))
+	}
+
+	if err := <-response; err != nil {
+		message := strings.ReplaceAll(err.Error(), "\n", "; ")
+		t.setStatus(fmt.Sprintf("[red]%s %s failed: %s", action, tview.Escape(project.Name), tview.Escape(message)))
+		return
+	}
+
+	t.setStatus(fmt.Sprintf("[green]%s %s done", action, tview.Escape(project.Name)))
+}
+
+func (t *Tui) execContainer() {
+	container, ok := t.selectedContainer()
+	if !ok {
+		return
+	}
+
+	var err error
+	t.app.Suspend(func() {
+		response := make(chan error)
+		t.requestData <- &RequestContainerExec{
+			ContainerID: container.ID,
+			Command:     t.execCommand,
+			Response:    response,
+		}
+
+		err = <-response
+	})
+
+	if err != nil {
+		t.status.SetText(fmt.Sprintf("[red]exec in %s failed: %s", tview.Escape(container.Name), tview.Escape(err.Error())))
+		return
+	}
+
+	t.status.SetText("")
+}
+
+func (t *Tui) confirm(text string, label string, onConfirm func()) {
+	focused := t.app.GetFocus()
+
+	modal := tview.NewModal().
+		SetText(text).
+		AddButtons([]string{"Cancel", label}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			t.pages.RemovePage(pageConfirm)
+			t.app.SetFocus(focused)
+
+			if buttonLabel == label {
+				onConfirm()
+			}
+		})
+
+	t.pages.AddPage(pageConfirm, modal, true, true)
+}
+
+// setStatus is safe to call from any goroutine.
+func (t *Tui) setStatus(text string) {
+	t.app.QueueUpdateDraw(func() {
+		t.status.SetText(text)
+	})
+}
@@ -0,0 +1,173 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const chartAxisWidth = 9
+
+// brailleDots maps a dot position inside a cell, 2 columns by 4 rows, to its bit in the braille block.
+var brailleDots = [2][4]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+var chartColors = []tcell.Color{
+	tcell.ColorAqua,
+	tcell.ColorYellow,
+	tcell.ColorFuchsia,
+	tcell.ColorLime,
+	tcell.ColorOrange,
+	tcell.ColorBlue,
+	tcell.ColorPink,
+	tcell.ColorTeal,
+}
+
+type chartPoint struct {
+	time  time.Time
+	value float64
+}
+
+type chartSeries struct {
+	name   string
+	color  tcell.Color
+	points []chartPoint
+}
+
+type chart struct {
+	*tview.Box
+	series []chartSeries
+	window time.Duration
+	end    time.Time
+	format func(float64) string
+}
+
+func newChart(format func(float64) string) *chart {
+	c := &chart{
+		Box:    tview.NewBox(),
+		format: format,
+	}
+	c.SetBorder(true)
+
+	return c
+}
+
+func (c *chart) Draw(screen tcell.Screen) {
+	c.DrawForSubclass(screen, c)
+
+	x, y, width, height := c.GetInnerRect()
+
+	legendHeight := min(len(c.series), max(0, height-2))
+	for index, series := range c.series[:legendHeight] {
+		minimum, maximum, average := seriesStats(series.points)
+		legend := fmt.Sprintf(
+			"[%s]■[-] %s  min %s  max %s  avg %s",
+			series.color.String(),
+			tview.Escape(series.name),
+			c.format(minimum),
+			c.format(maximum),
+			c.format(average),
+		)
+		tview.Print(screen, legend, x, y+index, width, tview.AlignLeft, tcell.ColorDefault)
+	}
+
+	plotX := x + chartAxisWidth
+	plotY := y + legendHeight
+	plotWidth := width - chartAxisWidth
+	plotHeight := height - legendHeight - 1
+	if plotWidth < 2 || plotHeight < 1 || c.window <= 0 {
+		return
+	}
+
+	top := 0.0
+	for _, series := range c.series {
+		for _, point := range series.points {
+			top = max(top, point.value)
+		}
+	}
+	if top == 0 {
+		top = 1
+	}
+
+	tview.Print(screen, c.format(top), x, plotY, chartAxisWidth-1, tview.AlignRight, tcell.ColorGray)
+	tview.Print(screen, c.format(0), x, plotY+plotHeight-1, chartAxisWidth-1, tview.AlignRight, tcell.ColorGray)
+
+	dotsWidth := plotWidth * 2
+	dotsHeight := plotHeight * 4
+	cells := make([]rune, plotWidth*plotHeight)
+	colors := make([]tcell.Color, plotWidth*plotHeight)
+
+	setDot := func(dotX int, dotY int, color tcell.Color) {
+		cell := (dotY/4)*plotWidth + dotX/2
+		cells[cell] |= brailleDots[dotX%2][dotY%4]
+		colors[cell] = color
+	}
+
+	start := c.end.Add(-c.window)
+	for _, series := range c.series {
+		previousX, previousY := -1, -1
+
+		for _, point := range series.points {
+			if point.time.Before(start) || point.time.After(c.end) {
+				continue
+			}
+
+			dotX := int(float64(point.time.Sub(start)) / float64(c.window) * float64(dotsWidth-1))
+			dotY := dotsHeight - 1 - int(max(0, point.value)/top*float64(dotsHeight-1))
+
+			if previousX < 0 {
+				setDot(dotX, dotY, series.color)
+			}
+
+			// Consecutive samples are joined by a straight line, filled vertically so steep slopes stay continuous.
+			lastY := previousY
+			for lineX := previousX + 1; previousX >= 0 && lineX <= dotX; lineX++ {
+				lineY := previousY + (dotY-previousY)*(lineX-previousX)/(dotX-previousX)
+				for fillY := min(lastY, lineY); fillY <= max(lastY, lineY); fillY++ {
+					setDot(lineX, fillY, series.color)
+				}
+
+				lastY = lineY
+			}
+
+			previousX, previousY = dotX, dotY
+		}
+	}
+
+	for index, dots := range cells {
+		if dots == 0 {
+			continue
+		}
+
+		screen.SetContent(
+			plotX+index%plotWidth,
+			plotY+index/plotWidth,
+			0x2800+dots,
+			nil,
+			tcell.StyleDefault.Foreground(colors[index]),
+		)
+	}
+
+	tview.Print(screen, "-"+formatWindow(c.window), plotX, y+height-1, plotWidth, tview.AlignLeft, tcell.ColorGray)
+	tview.Print(screen, "now", plotX, y+height-1, plotWidth, tview.AlignRight, tcell.ColorGray)
+}
+
+func seriesStats(points []chartPoint) (minimum float64, maximum float64, average float64) {
+	if len(points) == 0 {
+		return 0, 0, 0
+	}
+
+	minimum = points[0].value
+	total := 0.0
+	for _, point := range points {
+		minimum = min(minimum, point.value)
+		maximum = max(maximum, point.value)
+		total += point.value
+	}
+
+	return minimum, maximum, total / float64(len(points))
+}
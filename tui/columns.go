@@ -0,0 +1,484 @@
+package tui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/syrm/c8s/dto"
+)
+
+type column[T any] struct {
+	title string
+	// absoluteTitle replaces title when memory is displayed in bytes.
+	absoluteTitle string
+	align         int
+	expansion     int
+	// maxWidth caps the header width, 0 for no limit.
+	maxWidth int
+	optional bool
+	// text columns are first sorted in alphabetical order, the others from the highest value.
+	text bool
+	// compare is nil for columns which can't be sorted.
+	compare func(t *Tui, a, b T) int
+	cell    func(t *Tui, row T) *tview.TableCell
+}
+
+type sortState struct {
+	column     int
+	descending bool
+}
+
+var (
+	defaultSort              = sortState{column: 1, descending: true}
+	defaultAllContainersSort = sortState{column: 2, descending: true}
+)
+
+var projectColumns = []column[dto.Project]{
+	{
+		title:     "Project",
+		align:     tview.AlignLeft,
+		expansion: 3,
+		text:      true,
+		compare: func(t *Tui, a, b dto.Project) int {
+			return strings.Compare(a.Name, b.Name)
+		},
+		cell: func(t *Tui, p dto.Project) *tview.TableCell {
+			cell := tview.NewTableCell(tview.Escape(p.Name))
+			if p.ContainersCrashLooping > 0 {
+				cell.SetTextColor(tcell.ColorRed)
+			}
+
+			return cell
+		},
+	},
+	{
+		title:     "CPU",
+		align:     tview.AlignRight,
+		expansion: 2,
+		maxWidth:  7,
+		compare: func(t *Tui, a, b dto.Project) int {
+			return cmp.Compare(a.CPUPercentage, b.CPUPercentage)
+		},
+		cell: func(t *Tui, p dto.Project) *tview.TableCell {
+			return tview.NewTableCell(fmt.Sprintf("%.2f%%", max(0, p.CPUPercentage)))
+		},
+	},
+	{
+		title:         "Memory",
+		absoluteTitle: "Mem used / limit",
+		align:         tview.AlignRight,
+		expansion:     2,
+		maxWidth:      7,
+		compare: func(t *Tui, a, b dto.Project) int {
+			if t.memoryAbsolute {
+				return cmp.Compare(a.MemoryUsage, b.MemoryUsage)
+			}
+
+			return cmp.Compare(a.MemoryPercentage, b.MemoryPercentage)
+		},
+		cell: func(t *Tui, p dto.Project) *tview.TableCell {
+			return tview.NewTableCell(t.formatMemory(p.MemoryPercentage, p.MemoryUsage, p.MemoryLimit))
+		},
+	},
+	rateColumn("Net RX", func(p dto.Project) float64 { return p.NetworkRxRate }),
+	rateColumn("Net TX", func(p dto.Project) float64 { return p.NetworkTxRate }),
+	rateColumn("Blk R", func(p dto.Project) float64 { return p.BlockReadRate }),
+	rateColumn("Blk W", func(p dto.Project) float64 { return p.BlockWriteRate }),
+	{
+		title:     "Cont.",
+		align:     tview.AlignRight,
+		expansion: 2,
+		compare: func(t *Tui, a, b dto.Project) int {
+			return cmp.Compare(a.ContainersRunning, b.ContainersRunning)
+		},
+		cell: func(t *Tui, p dto.Project) *tview.TableCell {
+			return tview.NewTableCell(fmt.Sprintf("%d/%d", p.ContainersRunning, len(p.ContainersState)))
+		},
+	},
+	{
+		title:     "Health",
+		align:     tview.AlignLeft,
+		expansion: 2,
+		compare: func(t *Tui, a, b dto.Project) int {
+			return cmp.Compare(a.ContainersUnhealthy, b.ContainersUnhealthy)
+		},
+		cell: func(t *Tui, p dto.Project) *tview.TableCell {
+			if p.ContainersUnhealthy == 0 {
+				return tview.NewTableCell("")
+			}
+
+			return tview.NewTableCell(fmt.Sprintf("%d unhealthy", p.ContainersUnhealthy)).SetTextColor(tcell.ColorRed)
+		},
+	},
+	{
+		title: "CPU trend",
+		align: tview.AlignLeft,
+		cell: func(t *Tui, p dto.Project) *tview.TableCell {
+			return tview.NewTableCell(t.projectSparkline(p))
+		},
+	},
+	{
+		title:     "PIDs",
+		align:     tview.AlignRight,
+		expansion: 2,
+		optional:  true,
+		compare: func(t *Tui, a, b dto.Project) int {
+			return cmp.Compare(a.PidsCurrent, b.PidsCurrent)
+		},
+		cell: func(t *Tui, p dto.Project) *tview.TableCell {
+			return tview.NewTableCell(fmt.Sprintf("%d", p.PidsCurrent))
+		},
+	},
+	{
+		title:     "Throttled",
+		align:     tview.AlignRight,
+		expansion: 2,
+		optional:  true,
+		compare: func(t *Tui, a, b dto.Project) int {
+			return cmp.Compare(a.ContainersThrottled, b.ContainersThrottled)
+		},
+		cell: func(t *Tui, p dto.Project) *tview.TableCell {
+			cell := tview.NewTableCell(fmt.Sprintf("%d cont.", p.ContainersThrottled))
+			if p.ContainersThrottled > 0 {
+				cell.SetTextColor(tcell.ColorRed)
+			}
+
+			return cell
+		},
+	},
+}
+
+var containerColumns = []column[dto.Container]{
+	{
+		title:     "Container",
+		align:     tview.AlignLeft,
+		expansion: 2,
+		text:      true,
+		compare: func(t *Tui, a, b dto.Container) int {
+			return strings.Compare(a.Service, b.Service)
+		},
+		cell: func(t *Tui, c dto.Container) *tview.TableCell {
+			cell := tview.NewTableCell(tview.Escape(c.Service))
+			if c.CrashLooping {
+				cell.SetTextColor(tcell.ColorRed)
+			}
+
+			return cell
+		},
+	},
+	{
+		title:     "CPU",
+		align:     tview.AlignRight,
+		expansion: 2,
+		maxWidth:  7,
+		compare: func(t *Tui, a, b dto.Container) int {
+			return cmp.Compare(a.CPUPercentage, b.CPUPercentage)
+		},
+		cell: func(t *Tui, c dto.Container) *tview.TableCell {
+			return tview.NewTableCell(fmt.Sprintf("%.2f%%", c.CPUPercentage))
+		},
+	},
+	{
+		title:         "Memory",
+		absoluteTitle: "Mem used / limit",
+		align:         tview.AlignRight,
+		expansion:     2,
+		maxWidth:      7,
+		compare: func(t *Tui, a, b dto.Container) int {
+			if t.memoryAbsolute {
+				return cmp.Compare(a.MemoryUsage, b.MemoryUsage)
+			}
+
+			return cmp.Compare(a.MemoryPercentage, b.MemoryPercentage)
+		},
+		cell: func(t *Tui, c dto.Container) *tview.TableCell {
+			return tview.NewTableCell(t.formatMemory(c.MemoryPercentage, c.MemoryUsage, c.MemoryLimit))
+		},
+	},
+	rateColumn("Net RX", func(c dto.Container) float64 { return c.NetworkRxRate }),
+	rateColumn("Net TX", func(c dto.Container) float64 { return c.NetworkTxRate }),
+	rateColumn("Blk R", func(c dto.Container) float64 { return c.BlockReadRate }),
+	rateColumn("Blk W", func(c dto.Container) float64 { return c.BlockWriteRate }),
+	{
+		title:     "State",
+		align:     tview.AlignLeft,
+		expansion: 2,
+		compare: func(t *Tui, a, b dto.Container) int {
+			return cmp.Compare(stateSeverity[a.State], stateSeverity[b.State])
+		},
+		cell: func(t *Tui, c dto.Container) *tview.TableCell {
+			return stateCell(c)
+		},
+	},
+	{
+		title:     "Health",
+		align:     tview.AlignLeft,
+		expansion: 2,
+		compare: func(t *Tui, a, b dto.Container) int {
+			return cmp.Compare(healthSeverity[a.Health], healthSeverity[b.Health])
+		},
+		cell: func(t *Tui, c dto.Container) *tview.TableCell {
+			return healthCell(c)
+		},
+	},
+	{
+		title:     "Uptime",
+		align:     tview.AlignRight,
+		expansion: 2,
+		compare: func(t *Tui, a, b dto.Container) int {
+			// Containers which are not up count as no uptime, they come last in the default descending order.
+			_, upA := containerUptime(a)
+			_, upB := containerUptime(b)
+			switch {
+			case upA && !upB:
+				return 1
+			case !upA && upB:
+				return -1
+			case !upA && !upB:
+				return 0
+			}
+
+			return b.StartedAt.Compare(a.StartedAt)
+		},
+		cell: func(t *Tui, c dto.Container) *tview.TableCell {
+			return uptimeCell(c)
+		},
+	},
+	{
+		title:     "Restarts",
+		align:     tview.AlignRight,
+		expansion: 2,
+		compare: func(t *Tui, a, b dto.Container) int {
+			return cmp.Compare(a.RestartCount, b.RestartCount)
+		},
+		cell: func(t *Tui, c dto.Container) *tview.TableCell {
+			return restartsCell(c)
+		},
+	},
+	{
+		title: "CPU trend",
+		align: tview.AlignLeft,
+		cell: func(t *Tui, c dto.Container) *tview.TableCell {
+			return tview.NewTableCell(t.containerSparkline(c.ID))
+		},
+	},
+	{
+		title:     "PIDs",
+		align:     tview.AlignRight,
+		expansion: 2,
+		optional:  true,
+		compare: func(t *Tui, a, b dto.Container) int {
+			return cmp.Compare(a.PidsCurrent, b.PidsCurrent)
+		},
+		cell: func(t *Tui, c dto.Container) *tview.TableCell {
+			return pidsCell(c)
+		},
+	},
+	{
+		title:     "Throttled",
+		align:     tview.AlignRight,
+		expansion: 2,
+		optional:  true,
+		compare: func(t *Tui, a, b dto.Container) int {
+			return cmp.Compare(a.ThrottledPercentage, b.ThrottledPercentage)
+		},
+		cell: func(t *Tui, c dto.Container) *tview.TableCell {
+			return throttledCell(c)
+		},
+	},
+}
+
+var allContainerColumns = slices.Concat(containerColumns[:1], []column[dto.Container]{
+	{
+		title:     "Project",
+		align:     tview.AlignLeft,
+		expansion: 2,
+		text:      true,
+		compare: func(t *Tui, a, b dto.Container) int {
+			return strings.Compare(a.Project.Name, b.Project.Name)
+		},
+		cell: func(t *Tui, c dto.Container) *tview.TableCell {
+			return tview.NewTableCell(tview.Escape(c.Project.Name))
+		},
+	},
+}, containerColumns[1:])
+
+func rateColumn[T any](title string, rate func(T) float64) column[T] {
+	return column[T]{
+		title:     title,
+		align:     tview.AlignRight,
+		expansion: 2,
+		compare: func(t *Tui, a, b T) int {
+			return cmp.Compare(rate(a), rate(b))
+		},
+		cell: func(t *Tui, row T) *tview.TableCell {
+			return tview.NewTableCell(formatRate(rate(row)))
+		},
+	}
+}
+
+func visibleColumns[T any](t *Tui, columns []column[T]) []int {
+	var visible []int
+	for index, c := range columns {
+		if c.optional && !t.showOptionalColumns {
+			continue
+		}
+
+		visible = append(visible, index)
+	}
+
+	return visible
+}
+
+func renderHeader[T any](t *Tui, table *tview.Table, columns []column[T], sort sortState, firstTitle string) {
+	for position, index := range visibleColumns(t, columns) {
+		c := columns[index]
+
+		title := c.title
+		if position == 0 && firstTitle != "" {
+			title = firstTitle
+		}
+
+		maxWidth := c.maxWidth
+		if c.absoluteTitle != "" && t.memoryAbsolute {
+			title = c.absoluteTitle
+			maxWidth = 0
+		}
+
+		switch {
+		case index == sort.column && sort.descending:
+			title += " ▼"
+		case index == sort.column:
+			title += " ▲"
+		}
+
+		// The sort arrow is not cut.
+		if maxWidth > 0 && index == sort.column {
+			maxWidth += 2
+		}
+
+		align := c.align
+		if position == 0 {
+			align = tview.AlignCenter
+		}
+
+		table.SetCell(
+			0,
+			position,
+			tview.NewTableCell("[::b]"+tview.Escape(title)).
+				SetAlign(align).
+				SetExpansion(c.expansion).
+				SetMaxWidth(maxWidth).
+				SetReference(index).
+				SetSelectable(false),
+		)
+	}
+
+	table.SetFixed(1, 0)
+}
+
+func renderRow[T any](t *Tui, table *tview.Table, columns []column[T], rowIndex int, row T, reference any) {
+	for position, index := range visibleColumns(t, columns) {
+		cell := columns[index].cell(t, row).SetAlign(columns[index].align)
+		if position == 0 {
+			cell.SetReference(reference)
+		}
+
+		table.SetCell(rowIndex, position, cell)
+	}
+}
+
+// sortRows breaks ties on the name then the ID, rows come from maps.
+func sortRows[T any](t *Tui, rows []T, columns []column[T], sort sortState) {
+	compare := columns[sort.column].compare
+
+	slices.SortStableFunc(rows, func(a, b T) int {
+		result := compare(t, a, b)
+		if sort.descending {
+			result = -result
+		}
+
+		if result != 0 {
+			return result
+		}
+
+		return compareIdentity(a, b)
+	})
+}
+
+func compareIdentity[T any](a, b T) int {
+	switch a := any(a).(type) {
+	case dto.Project:
+		b := any(b).(dto.Project)
+		return cmp.Or(strings.Compare(a.Name, b.Name), strings.Compare(string(a.ID), string(b.ID)))
+	case dto.Container:
+		b := any(b).(dto.Container)
+		return cmp.Or(strings.Compare(a.Name, b.Name), strings.Compare(string(a.ID), string(b.ID)))
+	}
+
+	return 0
+}
+
+// cycleSort handles the sort keys: "<" and ">" move to the previous or next column, "R" reverses the order.
+func cycleSort[T any](t *Tui, columns []column[T], sort sortState, key rune) sortState {
+	switch key {
+	case '<':
+		return nextSortColumn(t, columns, sort, -1)
+	case '>':
+		return nextSortColumn(t, columns, sort, 1)
+	default:
+		return sortState{column: sort.column, descending: !sort.descending}
+	}
+}
+
+func nextSortColumn[T any](t *Tui, columns []column[T], sort sortState, direction int) sortState {
+	visible := visibleColumns(t, columns)
+	position := 0
+	for p, index := range visible {
+		if index == sort.column {
+			position = p
+		}
+	}
+
+	for range visible {
+		position = (position + direction + len(visible)) % len(visible)
+		if columns[visible[position]].compare != nil {
+			return sortState{column: visible[position], descending: !columns[visible[position]].text}
+		}
+	}
+
+	return sort
+}
+
+// sortOnHeaderClick reverses the order when the sorted column is clicked again.
+func sortOnHeaderClick[T any](table *tview.Table, columns []column[T], sort *sortState, redraw func()) func(tview.MouseAction, *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
+	return func(action tview.MouseAction, event *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
+		if action != tview.MouseLeftClick {
+			return action, event
+		}
+
+		row, position := table.CellAt(event.Position())
+		if row != 0 || position < 0 {
+			return action, event
+		}
+
+		index, ok := table.GetCell(row, position).GetReference().(int)
+		if !ok || columns[index].compare == nil {
+			return action, event
+		}
+
+		if index == sort.column {
+			*sort = sortState{column: index, descending: !sort.descending}
+		} else {
+			*sort = sortState{column: index, descending: !columns[index].text}
+		}
+
+		redraw()
+		return action, nil
+	}
+}
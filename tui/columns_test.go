@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/syrm/c8s/dto"
+)
+
+var testColumns = []column[dto.Container]{
+	{
+		title: "Service",
+		text:  true,
+		compare: func(t *Tui, a, b dto.Container) int {
+			return strings.Compare(a.Service, b.Service)
+		},
+	},
+	{
+		title: "CPU",
+		compare: func(t *Tui, a, b dto.Container) int {
+			return cmp.Compare(a.CPUPercentage, b.CPUPercentage)
+		},
+	},
+	{title: "Trend"},
+	{
+		title:    "PIDs",
+		optional: true,
+		compare: func(t *Tui, a, b dto.Container) int {
+			return cmp.Compare(a.PidsCurrent, b.PidsCurrent)
+		},
+	},
+}
+
+func containerIDs(containers []dto.Container) []dto.ContainerID {
+	ids := make([]dto.ContainerID, len(containers))
+	for index, container := range containers {
+		ids[index] = container.ID
+	}
+
+	return ids
+}
+
+func TestSortRows(t *testing.T) {
+	containers := []dto.Container{
+		{ID: "4", Name: "web-2", Service: "web", CPUPercentage: 10},
+		{ID: "1", Name: "db", Service: "db", CPUPercentage: 50},
+		{ID: "3", Name: "web-1", Service: "web", CPUPercentage: 10},
+		{ID: "2", Name: "web-1", Service: "web", CPUPercentage: 10},
+	}
+
+	tests := []struct {
+		name string
+		sort sortState
+		want []dto.ContainerID
+	}{
+		{name: "ascending", sort: sortState{column: 0}, want: []dto.ContainerID{"1", "2", "3", "4"}},
+		{name: "descending", sort: sortState{column: 0, descending: true}, want: []dto.ContainerID{"2", "3", "4", "1"}},
+		{name: "numeric descending", sort: sortState{column: 1, descending: true}, want: []dto.ContainerID{"1", "2", "3", "4"}},
+		{name: "numeric ascending", sort: sortState{column: 1}, want: []dto.ContainerID{"2", "3", "4", "1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Ties must not depend on the order rows come in, they come from maps.
+			reversed := slices.Clone(containers)
+			slices.Reverse(reversed)
+
+			for _, rows := range [][]dto.Container{slices.Clone(containers), reversed} {
+				sortRows(&Tui{}, rows, testColumns, tt.sort)
+				if got := containerIDs(rows); !slices.Equal(got, tt.want) {
+					t.Errorf("sortRows() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCycleSort(t *testing.T) {
+	tests := []struct {
+		name     string
+		optional bool
+		sort     sortState
+		key      rune
+		want     sortState
+	}{
+		{name: "next skips the unsortable column and wraps", sort: sortState{column: 1, descending: true}, key: '>', want: sortState{column: 0}},
+		{name: "previous wraps and skips the unsortable column", sort: sortState{column: 0}, key: '<', want: sortState{column: 1, descending: true}},
+		{name: "next reaches the optional column once shown", optional: true, sort: sortState{column: 1}, key: '>', want: sortState{column: 3, descending: true}},
+		{name: "next wraps from the optional column", optional: true, sort: sortState{column: 3, descending: true}, key: '>', want: sortState{column: 0}},
+		{name: "reverse", sort: sortState{column: 1, descending: true}, key: 'R', want: sortState{column: 1}},
+		{name: "reverse back", sort: sortState{column: 1}, key: 'R', want: sortState{column: 1, descending: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cycleSort(&Tui{showOptionalColumns: tt.optional}, testColumns, tt.sort, tt.key); got != tt.want {
+				t.Errorf("cycleSort(%v, %q) = %v, want %v", tt.sort, tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextSortColumnWithoutSortableColumn(t *testing.T) {
+	columns := []column[dto.Container]{{title: "Trend"}, {title: "Other"}}
+	sort := sortState{column: 0}
+
+	if got := nextSortColumn(&Tui{}, columns, sort, 1); got != sort {
+		t.Errorf("nextSortColumn() = %v, want %v", got, sort)
+	}
+}
+
+func TestCompareIdentity(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b dto.Project
+		want int
+	}{
+		{name: "name first", a: dto.Project{ID: "2", Name: "a"}, b: dto.Project{ID: "1", Name: "b"}, want: -1},
+		{name: "then ID", a: dto.Project{ID: "2", Name: "a"}, b: dto.Project{ID: "1", Name: "a"}, want: 1},
+		{name: "same", a: dto.Project{ID: "1", Name: "a"}, b: dto.Project{ID: "1", Name: "a"}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compareIdentity(tt.a, tt.b); got != tt.want {
+				t.Errorf("compareIdentity() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNameCellsAreEscaped(t *testing.T) {
+	if got := projectColumns[0].cell(&Tui{}, dto.Project{Name: "[red]shop"}).Text; got != "[red[]shop" {
+		t.Errorf("project cell = %q, want the name escaped", got)
+	}
+
+	if got := containerColumns[0].cell(&Tui{}, dto.Container{Service: "[red]web"}).Text; got != "[red[]web" {
+		t.Errorf("container cell = %q, want the service escaped", got)
+	}
+}
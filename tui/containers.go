@@ -0,0 +1,260 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/syrm/c8s/dto"
+)
+
+// viewMode is the top-level view cycled with "v".
+type viewMode int
+
+const (
+	viewModeProjects viewMode = iota
+	viewModeContainers
+	viewModeTree
+)
+
+func (t *Tui) cycleViewMode() {
+	t.viewMode = (t.viewMode + 1) % (viewModeTree + 1)
+
+	switch t.viewMode {
+	case viewModeContainers:
+		t.redrawAllContainers()
+		t.showContainerTable()
+	case viewModeTree:
+		t.redrawTree()
+		t.showProjectTable()
+	default:
+		t.redrawProjects()
+		t.showProjectTable()
+	}
+}
+
+// topDraw returns the draw function of the top-level view, which needs t.tableProjectDataLock held.
+func (t *Tui) topDraw() func() {
+	switch t.viewMode {
+	case viewModeContainers:
+		return t.drawAllContainers
+	case viewModeTree:
+		return t.drawTree
+	}
+
+	return t.drawProjects
+}
+
+func (t *Tui) redrawTop() {
+	t.tableProjectDataLock.RLock()
+	defer t.tableProjectDataLock.RUnlock()
+
+	t.topDraw()()
+}
+
+func (t *Tui) projectTable() (*tview.Table, currentView) {
+	if t.viewMode == viewModeTree {
+		return t.tableTree, viewProjectTree
+	}
+
+	return t.tableProject, viewProjectList
+}
+
+func (t *Tui) showProjectTable() {
+	table, view := t.projectTable()
+
+	t.currentViewLock.Lock()
+	t.currentView = view
+	t.currentViewLock.Unlock()
+
+	t.setView(table)
+}
+
+// containerTable returns the table the container logs, detail and graph are opened from.
+func (t *Tui) containerTable() (*tview.Table, currentView) {
+	switch t.viewMode {
+	case viewModeContainers:
+		return t.tableAllContainers, viewContainerList
+	case viewModeTree:
+		return t.tableTree, viewProjectTree
+	}
+
+	return t.tableContainer, viewProject
+}
+
+func (t *Tui) showContainerTable() {
+	table, view := t.containerTable()
+
+	t.currentViewLock.Lock()
+	t.currentView = view
+	t.currentViewLock.Unlock()
+
+	t.setView(table)
+}
+
+func (t *Tui) redrawAllContainers() {
+	t.tableProjectDataLock.RLock()
+	defer t.tableProjectDataLock.RUnlock()
+
+	t.drawAllContainers()
+}
+
+// drawAllContainers lists the containers of the project list snapshot, t.tableProjectDataLock must be held.
+// A container belonging to several projects, like when grouping by network, is listed once.
+func (t *Tui) drawAllContainers() {
+	t.allContainers = make(map[dto.ContainerID]dto.Container)
+	projectNames := make(map[dto.ContainerID][]string)
+	for _, project := range t.tableProjectData {
+		for _, container := range project.Containers {
+			t.allContainers[container.ID] = container
+			projectNames[container.ID] = append(projectNames[container.ID], container.Project.Name)
+		}
+	}
+
+	for id, names := range projectNames {
+		if len(names) > 1 {
+			container := t.allContainers[id]
+			slices.Sort(names)
+			container.Project.Name = strings.Join(names, ", ")
+			t.allContainers[id] = container
+		}
+	}
+
+	var containers []dto.Container
+	for _, container := range t.allContainers {
+		if _, ok := fuzzyMatch(t.allContainersFilter, container.Project.Name); ok || containerMatchesFilter(t.allContainersFilter, container) {
+			containers = append(containers, container)
+		}
+	}
+
+	sortRows(t, containers, allContainerColumns, t.allContainersSort)
+
+	selected, hasSelection := selectedReference[dto.ContainerID](t.tableAllContainers)
+	t.tableAllContainers.Clear()
+	renderHeader(t, t.tableAllContainers, allContainerColumns, t.allContainersSort, "")
+	for index, container := range containers {
+		renderRow(t, t.tableAllContainers, allContainerColumns, index+1, container, container.ID)
+
+		if indexes, ok := fuzzyMatch(t.allContainersFilter, container.Service); ok {
+			cell := t.tableAllContainers.GetCell(index+1, 0)
+			cell.SetText(highlightMatches(container.Service, indexes, cell.Color))
+		}
+	}
+
+	if hasSelection {
+		selectReference(t.tableAllContainers, selected)
+	}
+
+	t.tableAllContainers.SetTitle(filterTitle(t.allContainersFilter))
+}
+
+func (t *Tui) containerKeys(
+	event *tcell.EventKey,
+	table *tview.Table,
+	sortKeys func(key rune),
+	filter *string,
+	redraw func(),
+) *tcell.EventKey {
+	if event.Key() == tcell.KeyEnter {
+		t.openContainerLogs()
+		return nil
+	}
+
+	if event.Key() != tcell.KeyRune {
+		return event
+	}
+
+	switch event.Rune() {
+	case 'e':
+		t.execContainer()
+		return nil
+	case '/':
+		t.openTableFilter(table, filter, redraw)
+		return nil
+	case 'i':
+		t.openContainerDetail()
+		return nil
+	case 'H':
+		t.openContainerHealth()
+		return nil
+	case 'E':
+		t.openContainerTimeline()
+		return nil
+	case 'm':
+		t.memoryAbsolute = !t.memoryAbsolute
+		redraw()
+		return nil
+	case 'o':
+		t.toggleOptionalColumns()
+		redraw()
+		return nil
+	case '<', '>', 'R':
+		sortKeys(event.Rune())
+		redraw()
+		return nil
+	case 'c':
+		t.openContainerGraph()
+		return nil
+	}
+
+	if action, ok := containerActionKeys[event.Rune()]; ok {
+		t.runContainerAction(action)
+		return nil
+	}
+
+	return event
+}
+
+func (t *Tui) projectKeys(
+	event *tcell.EventKey,
+	table *tview.Table,
+	sortKeys func(key rune),
+	filter *string,
+	redraw func(),
+) *tcell.EventKey {
+	if event.Key() != tcell.KeyRune {
+		return event
+	}
+
+	switch event.Rune() {
+	case 'L':
+		t.openProjectLogs()
+		return nil
+	case 'b':
+		t.cycleGrouping()
+		return nil
+	case 'B':
+		t.openLabelGrouping()
+		return nil
+	case '/':
+		t.openTableFilter(table, filter, redraw)
+		return nil
+	case 'c':
+		t.openProjectGraph()
+		return nil
+	case 'E':
+		t.openProjectTimeline()
+		return nil
+	case 'm':
+		t.memoryAbsolute = !t.memoryAbsolute
+		redraw()
+		return nil
+	case 'o':
+		t.toggleOptionalColumns()
+		redraw()
+		return nil
+	case '<', '>', 'R':
+		sortKeys(event.Rune())
+		redraw()
+		return nil
+	}
+
+	if action, ok := projectActionKeys[event.Rune()]; ok {
+		t.runProjectAction(action)
+		return nil
+	}
+
+	return event
+}
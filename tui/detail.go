@@ -0,0 +1,238 @@
+package tui
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/syrm/c8s/dto"
+)
+
+// secretEnvName only matches whole segments of the name, "AUTHOR" or "MONKEY" are not secrets.
+var secretEnvName = regexp.MustCompile(`(?i)(^|[_.-])(pass(word|wd)?|secret|token|api_?key|key|credentials?|auth|private)([_.-]|$)`)
+
+func (t *Tui) openContainerDetail() {
+	t.inspectSelectedContainer(func(container dto.Container, detail dto.ContainerDetail) func() {
+		timeline := &RequestTimeline{
+			ContainerID: container.ID,
+			Response:    make(chan []dto.Event),
+		}
+		t.requestData <- timeline
+		events := <-timeline.Response
+
+		return func() {
+			t.showContainerDetail(detail, events)
+		}
+	})
+}
+
+// inspectSelectedContainer inspects the selected container in the background, then loads what else is needed.
+// The function returned by load is dropped if the user moved to another view or container meanwhile.
+func (t *Tui) inspectSelectedContainer(load func(container dto.Container, detail dto.ContainerDetail) func()) {
+	container, ok := t.selectedContainer()
+	if !ok {
+		return
+	}
+
+	t.currentViewLock.RLock()
+	view := t.currentView
+	t.currentViewLock.RUnlock()
+
+	t.status.SetText(fmt.Sprintf("inspecting %s…", tview.Escape(container.Name)))
+
+	go func() {
+		response := make(chan ContainerInspectResponse)
+		t.requestData <- &RequestContainerInspect{
+			ContainerID: container.ID,
+			Response:    response,
+		}
+
+		inspect := <-response
+		if inspect.Err != nil {
+			t.setStatus(fmt.Sprintf("[red]inspect %s failed: %s", container.Name, tview.Escape(inspect.Err.Error())))
+			return
+		}
+
+		show := load(container, inspect.Detail)
+
+		t.app.QueueUpdateDraw(func() {
+			t.currentViewLock.RLock()
+			moved := t.currentView != view
+			t.currentViewLock.RUnlock()
+
+			if selected, ok := t.selectedContainer(); moved || !ok || selected.ID != container.ID {
+				t.status.SetText("")
+				return
+			}
+
+			t.status.SetText("")
+			show()
+		})
+	}()
+}
+
+func (t *Tui) showContainerDetail(detail dto.ContainerDetail, events []dto.Event) {
+	root := tview.NewTreeNode("[::b]" + tview.Escape(detail.Name))
+
+	tree := tview.NewTreeView().
+		SetRoot(root).
+		SetCurrentNode(root)
+	tree.SetBorder(true).SetTitle(" " + tview.Escape(detail.Name) + " ")
+
+	tree.SetSelectedFunc(func(node *tview.TreeNode) {
+		node.SetExpanded(!node.IsExpanded())
+	})
+
+	tree.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			t.showContainerTable()
+			return nil
+		}
+
+		return event
+	})
+
+	overview := addDetailSection(root, "Overview", true)
+	addDetailLeaf(overview, "Image", detail.Image)
+	addDetailLeaf(overview, "Image ID", detail.ImageID)
+	for _, digest := range detail.ImageDigests {
+		addDetailLeaf(overview, "Digest", digest)
+	}
+	addDetailLeaf(overview, "Status", detail.Status)
+	if detail.Health != "" {
+		addDetailLeaf(overview, "Health", detail.Health)
+	}
+	addDetailLeaf(overview, "Exit code", fmt.Sprint(detail.ExitCode))
+	addDetailLeaf(overview, "Restart policy", restartPolicy(detail))
+	addDetailLeaf(overview, "Created", formatDetailTime(detail.Created))
+	addDetailLeaf(overview, "Started", formatDetailTime(detail.StartedAt))
+	addDetailLeaf(overview, "Finished", formatDetailTime(detail.FinishedAt))
+
+	command := addDetailSection(root, "Command", true)
+	addDetailLeaf(command, "Entrypoint", maskArguments(detail.Entrypoint))
+	addDetailLeaf(command, "Command", maskArguments(detail.Command))
+
+	env := addDetailSection(root, fmt.Sprintf("Environment (%d)", len(detail.Env)), false)
+	for _, variable := range detail.Env {
+		name, value, _ := strings.Cut(variable, "=")
+		env.AddChild(tview.NewTreeNode(fmt.Sprintf("[yellow]%s[-]=%s", tview.Escape(name), maskSecret(name, value))))
+	}
+
+	labels := addDetailSection(root, fmt.Sprintf("Labels (%d)", len(detail.Labels)), false)
+	for _, name := range slices.Sorted(maps.Keys(detail.Labels)) {
+		labels.AddChild(tview.NewTreeNode(fmt.Sprintf("[yellow]%s[-]: %s", tview.Escape(name), maskSecret(name, detail.Labels[name]))))
+	}
+
+	mounts := addDetailSection(root, fmt.Sprintf("Mounts (%d)", len(detail.Mounts)), true)
+	for _, mount := range detail.Mounts {
+		mode := "ro"
+		if mount.ReadWrite {
+			mode = "rw"
+		}
+
+		addDetailLeaf(mounts, mount.Type, fmt.Sprintf("%s → %s (%s)", mount.Source, mount.Destination, mode))
+	}
+
+	networks := addDetailSection(root, fmt.Sprintf("Networks (%d)", len(detail.Networks)), true)
+	for _, network := range detail.Networks {
+		node := addDetailSection(networks, network.Name, true)
+		addDetailLeaf(node, "IP", network.IPAddress)
+		addDetailLeaf(node, "Gateway", network.Gateway)
+		addDetailLeaf(node, "MAC", network.MacAddress)
+	}
+
+	ports := addDetailSection(root, fmt.Sprintf("Ports (%d)", len(detail.Ports)), true)
+	for _, port := range detail.Ports {
+		if port.HostPort == "" {
+			addDetailLeaf(ports, port.ContainerPort, "not published")
+			continue
+		}
+
+		addDetailLeaf(ports, port.ContainerPort, fmt.Sprintf("%s:%s", port.HostIP, port.HostPort))
+	}
+
+	timeline := addDetailSection(root, fmt.Sprintf("Events (%d)", len(events)), false)
+	for _, event := range slices.Backward(events) {
+		name := event.Action
+		if event.Type != "container" {
+			name = event.Type + " " + event.Name + " " + event.Action
+		}
+
+		addDetailLeaf(timeline, formatEventTime(event.Time), strings.TrimSpace(name+" "+formatEventAttributes(event.Attributes)))
+	}
+
+	t.currentViewLock.Lock()
+	t.currentView = viewDetail
+	t.currentViewLock.Unlock()
+
+	t.setView(tree)
+	t.status.SetText("[::d]enter expand/collapse  esc back")
+}
+
+func addDetailSection(parent *tview.TreeNode, name string, expanded bool) *tview.TreeNode {
+	node := tview.NewTreeNode("[::b]" + tview.Escape(name)).
+		SetColor(tcell.ColorAqua).
+		SetExpanded(expanded)
+	parent.AddChild(node)
+
+	return node
+}
+
+func addDetailLeaf(parent *tview.TreeNode, name string, value string) {
+	parent.AddChild(tview.NewTreeNode(fmt.Sprintf("[yellow]%s[-]: %s", tview.Escape(name), tview.Escape(value))))
+}
+
+func restartPolicy(detail dto.ContainerDetail) string {
+	if detail.RestartPolicy == "" {
+		return "no"
+	}
+
+	if detail.MaximumRetryCount > 0 {
+		return fmt.Sprintf("%s:%d", detail.RestartPolicy, detail.MaximumRetryCount)
+	}
+
+	return detail.RestartPolicy
+}
+
+func formatDetailTime(value time.Time) string {
+	if value.IsZero() || value.Year() <= 1 {
+		return "-"
+	}
+
+	return value.Local().Format("2006-01-02 15:04:05")
+}
+
+func maskSecret(name string, value string) string {
+	if secretEnvName.MatchString(name) && value != "" {
+		return "[gray]********[-]"
+	}
+
+	return tview.Escape(value)
+}
+
+// maskArguments hides the values of the options looking like secrets, like "--password=value" or "--token value".
+func maskArguments(arguments []string) string {
+	masked := make([]string, len(arguments))
+	secretNext := false
+
+	for index, argument := range arguments {
+		switch name, value, found := strings.Cut(argument, "="); {
+		case secretNext:
+			masked[index] = "********"
+		case found && strings.HasPrefix(name, "-") && secretEnvName.MatchString(name) && value != "":
+			masked[index] = name + "=********"
+		default:
+			masked[index] = argument
+		}
+
+		secretNext = !secretNext && strings.HasPrefix(argument, "-") && !strings.Contains(argument, "=") && secretEnvName.MatchString(argument)
+	}
+
+	return strings.Join(masked, " ")
+}
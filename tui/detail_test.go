@@ -0,0 +1,59 @@
+package tui
+
+import "testing"
+
+func TestMaskSecret(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "POSTGRES_PASSWORD", value: "hunter2", want: "[gray]********[-]"},
+		{name: "MYSQL_ROOT_PASSWORD_FILE", value: "/run/secrets/db", want: "[gray]********[-]"},
+		{name: "GITHUB_TOKEN", value: "ghp_x", want: "[gray]********[-]"},
+		{name: "AWS_SECRET_ACCESS_KEY", value: "x", want: "[gray]********[-]"},
+		{name: "api_key", value: "x", want: "[gray]********[-]"},
+		{name: "APIKEY", value: "x", want: "[gray]********[-]"},
+		{name: "traefik.http.middlewares.auth.basicauth.users", value: "x", want: "[gray]********[-]"},
+		{name: "private-key", value: "x", want: "[gray]********[-]"},
+		{name: "PASSWORD", value: "", want: ""},
+		{name: "org.opencontainers.image.authors", value: "Jane", want: "Jane"},
+		{name: "AUTHOR", value: "Jane", want: "Jane"},
+		{name: "MONKEY", value: "banana", want: "banana"},
+		{name: "KEYBOARD_LAYOUT", value: "fr", want: "fr"},
+		{name: "PATH", value: "/usr/bin", want: "/usr/bin"},
+		{name: "COMPOSE_PROJECT", value: "[web]", want: "[web[]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := maskSecret(tt.name, tt.value); got != tt.want {
+				t.Errorf("maskSecret(%q, %q) = %q, want %q", tt.name, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaskArguments(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments []string
+		want      string
+	}{
+		{name: "no argument", want: ""},
+		{name: "no secret", arguments: []string{"nginx", "-g", "daemon off;"}, want: "nginx -g daemon off;"},
+		{name: "option with value", arguments: []string{"app", "--password=hunter2"}, want: "app --password=********"},
+		{name: "option then value", arguments: []string{"app", "--token", "abc", "--port", "80"}, want: "app --token ******** --port 80"},
+		{name: "empty value", arguments: []string{"app", "--api-key="}, want: "app --api-key="},
+		{name: "harmless option", arguments: []string{"app", "--author", "jane", "--keyboard=fr"}, want: "app --author jane --keyboard=fr"},
+		{name: "secret word as value", arguments: []string{"echo", "token"}, want: "echo token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := maskArguments(tt.arguments); got != tt.want {
+				t.Errorf("maskArguments(%q) = %q, want %q", tt.arguments, got, tt.want)
+			}
+		})
+	}
+}
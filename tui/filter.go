@@ -0,0 +1,123 @@
+package tui
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/syrm/c8s/dto"
+)
+
+// fuzzyMatch returns the indexes of the runes of text matching pattern in order, ignoring case.
+func fuzzyMatch(pattern string, text string) ([]int, bool) {
+	patternRunes := []rune(strings.ToLower(pattern))
+	if len(patternRunes) == 0 {
+		return nil, true
+	}
+
+	var indexes []int
+	for index, r := range []rune(text) {
+		if unicode.ToLower(r) != patternRunes[len(indexes)] {
+			continue
+		}
+
+		indexes = append(indexes, index)
+		if len(indexes) == len(patternRunes) {
+			return indexes, true
+		}
+	}
+
+	return nil, false
+}
+
+// highlightMatches restores color after each match, a red row stays red.
+func highlightMatches(text string, indexes []int, color tcell.Color) string {
+	if len(indexes) == 0 {
+		return tview.Escape(text)
+	}
+
+	restore := "[-::-]"
+	if color != tcell.ColorDefault {
+		restore = "[" + color.String() + "::-]"
+	}
+
+	// The text between matches is escaped as a whole, escaping rune by rune would leave "[red]" as a tag.
+	var b strings.Builder
+	runes := []rune(text)
+	start := 0
+	for _, index := range indexes {
+		b.WriteString(tview.Escape(string(runes[start:index])))
+		b.WriteString("[yellow::b]" + tview.Escape(string(runes[index])) + restore)
+		start = index + 1
+	}
+	b.WriteString(tview.Escape(string(runes[start:])))
+
+	return b.String()
+}
+
+// Labels are matched as "key=value" substrings, a fuzzy match would accept almost anything on long compose labels.
+func containerMatchesFilter(filter string, container dto.Container) bool {
+	for _, text := range []string{container.Service, container.Name, container.Image} {
+		if _, ok := fuzzyMatch(filter, text); ok {
+			return true
+		}
+	}
+
+	filter = strings.ToLower(filter)
+	for key, value := range container.Labels {
+		if strings.Contains(strings.ToLower(key+"="+value), filter) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func projectMatchesFilter(filter string, project dto.Project) bool {
+	if _, ok := fuzzyMatch(filter, project.Name); ok {
+		return true
+	}
+
+	for _, container := range project.Containers {
+		if containerMatchesFilter(filter, container) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// openTableFilter filters the rows while typing, enter keeps the filter and escape clears it.
+func (t *Tui) openTableFilter(table *tview.Table, filter *string, redraw func()) {
+	input := tview.NewInputField().SetLabel("/").SetText(*filter)
+
+	input.SetChangedFunc(func(text string) {
+		*filter = text
+		redraw()
+	})
+
+	input.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape {
+			*filter = ""
+			redraw()
+		}
+
+		t.layout.RemoveItem(input)
+		t.layout.AddItem(t.status, 1, 0, false)
+		t.app.SetFocus(table)
+	})
+
+	t.layout.RemoveItem(t.status)
+	t.layout.AddItem(input, 1, 0, true)
+	t.app.SetFocus(input)
+}
+
+func filterTitle(filter string) string {
+	if filter == "" {
+		return ""
+	}
+
+	return " filter: " + tview.Escape(filter) + " "
+}
@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		pattern     string
+		text        string
+		wantIndexes []int
+		wantOk      bool
+	}{
+		{name: "empty pattern matches anything", pattern: "", text: "web", wantOk: true},
+		{name: "empty pattern matches empty text", pattern: "", text: "", wantOk: true},
+		{name: "empty text", pattern: "w", text: "", wantOk: false},
+		{name: "exact", pattern: "web", text: "web", wantIndexes: []int{0, 1, 2}, wantOk: true},
+		{name: "subsequence", pattern: "wb", text: "web", wantIndexes: []int{0, 2}, wantOk: true},
+		{name: "case insensitive", pattern: "WeB", text: "wEb", wantIndexes: []int{0, 1, 2}, wantOk: true},
+		{name: "out of order", pattern: "bw", text: "web", wantOk: false},
+		{name: "pattern longer than text", pattern: "webs", text: "web", wantOk: false},
+		{name: "repeated runes", pattern: "oo", text: "foo", wantIndexes: []int{1, 2}, wantOk: true},
+		{name: "repeated runes missing", pattern: "ooo", text: "foo", wantOk: false},
+		{name: "indexes are runes", pattern: "éb", text: "réseau-web", wantIndexes: []int{1, 9}, wantOk: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indexes, ok := fuzzyMatch(tt.pattern, tt.text)
+			if ok != tt.wantOk || !slices.Equal(indexes, tt.wantIndexes) {
+				t.Errorf("fuzzyMatch(%q, %q) = %v, %v, want %v, %v", tt.pattern, tt.text, indexes, ok, tt.wantIndexes, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestHighlightMatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		indexes []int
+		color   tcell.Color
+		want    string
+	}{
+		{name: "no match", text: "web[1]", color: tcell.ColorDefault, want: "web[1[]"},
+		{name: "matches", text: "web", indexes: []int{0, 2}, color: tcell.ColorDefault, want: "[yellow::b]w[-::-]e[yellow::b]b[-::-]"},
+		{name: "keeps the cell colour", text: "web", indexes: []int{0}, color: tcell.ColorRed, want: "[yellow::b]w[red::-]eb"},
+		{name: "escapes matched runes", text: "[a]", indexes: []int{2}, color: tcell.ColorDefault, want: "[a[yellow::b]][-::-]"},
+		{name: "escapes the text between matches", text: "[red]x", indexes: []int{5}, color: tcell.ColorDefault, want: "[red[][yellow::b]x[-::-]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := highlightMatches(tt.text, tt.indexes, tt.color); got != tt.want {
+				t.Errorf("highlightMatches(%q, %v) = %q, want %q", tt.text, tt.indexes, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/syrm/c8s/dto"
+)
+
+var byteUnits = []string{"B", "kB", "MB", "GB", "TB"}
+
+// formatBytes uses decimal units like the docker CLI.
+func formatBytes(bytes float64) string {
+	unit := 0
+	for bytes >= 1000 && unit < len(byteUnits)-1 {
+		bytes /= 1000
+		unit++
+	}
+
+	if unit == 0 {
+		return fmt.Sprintf("%.0f%s", bytes, byteUnits[unit])
+	}
+
+	return fmt.Sprintf("%.1f%s", bytes, byteUnits[unit])
+}
+
+func formatRate(bytesPerSecond float64) string {
+	return formatBytes(max(0, bytesPerSecond)) + "/s"
+}
+
+func pidsCell(container dto.Container) *tview.TableCell {
+	if container.PidsLimit == 0 {
+		return tview.NewTableCell(fmt.Sprintf("%d/∞", container.PidsCurrent)).SetAlign(tview.AlignRight)
+	}
+
+	cell := tview.NewTableCell(fmt.Sprintf("%d/%d", container.PidsCurrent, container.PidsLimit)).SetAlign(tview.AlignRight)
+	if container.PidsCurrent*10 >= container.PidsLimit*9 {
+		cell.SetTextColor(tcell.ColorYellow)
+	}
+
+	return cell
+}
+
+func throttledCell(container dto.Container) *tview.TableCell {
+	cell := tview.NewTableCell(
+		fmt.Sprintf("%.1f%% %.2fs/s", container.ThrottledPercentage, container.ThrottledTimeRate),
+	).SetAlign(tview.AlignRight)
+
+	if container.ThrottledPercentage > 0 {
+		cell.SetTextColor(tcell.ColorRed)
+	}
+
+	return cell
+}
+
+var healthSeverity = map[dto.HealthStatus]int{
+	dto.HealthUnhealthy: 3,
+	dto.HealthStarting:  2,
+	dto.HealthHealthy:   1,
+}
+
+func healthCell(container dto.Container) *tview.TableCell {
+	switch container.Health {
+	case dto.HealthUnhealthy:
+		return tview.NewTableCell(string(container.Health)).SetTextColor(tcell.ColorRed)
+	case dto.HealthStarting:
+		return tview.NewTableCell(string(container.Health)).SetTextColor(tcell.ColorYellow)
+	case dto.HealthHealthy:
+		return tview.NewTableCell(string(container.Health)).SetTextColor(tcell.ColorGreen)
+	}
+
+	return tview.NewTableCell(string(dto.HealthNone)).SetAttributes(tcell.AttrDim)
+}
+
+var stateColors = map[dto.ContainerState]tcell.Color{
+	dto.StateRunning:    tcell.ColorGreen,
+	dto.StatePaused:     tcell.ColorYellow,
+	dto.StateRestarting: tcell.ColorOrange,
+	dto.StateDead:       tcell.ColorRed,
+}
+
+var stateSeverity = map[dto.ContainerState]int{
+	dto.StateDead:       6,
+	dto.StateRestarting: 5,
+	dto.StateExited:     4,
+	dto.StatePaused:     3,
+	dto.StateRemoving:   2,
+	dto.StateCreated:    1,
+}
+
+func stateCell(container dto.Container) *tview.TableCell {
+	text := string(container.State)
+	if container.State == dto.StateExited && container.ExitCode != 0 {
+		text = fmt.Sprintf("%s (%d)", text, container.ExitCode)
+	}
+	if container.OOMKilled {
+		text += " OOM"
+	}
+
+	cell := tview.NewTableCell(" " + text + " ")
+
+	color, ok := stateColors[container.State]
+	switch {
+	case ok:
+		cell.SetBackgroundColor(color).SetTextColor(tcell.ColorBlack)
+	case container.State == dto.StateExited && (container.ExitCode != 0 || container.OOMKilled):
+		cell.SetBackgroundColor(tcell.ColorRed).SetTextColor(tcell.ColorBlack)
+	default:
+		cell.SetAttributes(tcell.AttrDim)
+	}
+
+	return cell
+}
+
+// formatUptime keeps the two most significant units, like "3d4h" or "5m12s".
+func formatUptime(uptime time.Duration) string {
+	uptime = uptime.Truncate(time.Second)
+
+	days := uptime / (24 * time.Hour)
+	hours := uptime % (24 * time.Hour) / time.Hour
+	minutes := uptime % time.Hour / time.Minute
+	seconds := uptime % time.Minute / time.Second
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd%dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	case minutes > 0:
+		return fmt.Sprintf("%dm%ds", minutes, seconds)
+	}
+
+	return fmt.Sprintf("%ds", seconds)
+}
+
+func uptimeCell(container dto.Container) *tview.TableCell {
+	uptime, ok := containerUptime(container)
+	if !ok {
+		return tview.NewTableCell("-").SetAttributes(tcell.AttrDim)
+	}
+
+	return tview.NewTableCell(formatUptime(uptime))
+}
+
+func containerUptime(container dto.Container) (time.Duration, bool) {
+	if container.StartedAt.IsZero() || (container.State != dto.StateRunning && container.State != dto.StatePaused) {
+		return 0, false
+	}
+
+	return time.Since(container.StartedAt), true
+}
+
+func restartsCell(container dto.Container) *tview.TableCell {
+	cell := tview.NewTableCell(fmt.Sprintf("%d", container.RestartCount))
+	if container.CrashLooping {
+		cell.SetText(fmt.Sprintf("%d crash loop", container.RestartCount)).SetTextColor(tcell.ColorRed)
+	}
+
+	return cell
+}
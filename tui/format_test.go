@@ -0,0 +1,29 @@
+package tui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatUptime(t *testing.T) {
+	tests := []struct {
+		uptime time.Duration
+		want   string
+	}{
+		{uptime: 0, want: "0s"},
+		{uptime: 1500 * time.Millisecond, want: "1s"},
+		{uptime: 59 * time.Second, want: "59s"},
+		{uptime: time.Minute, want: "1m0s"},
+		{uptime: 90*time.Minute + 30*time.Second, want: "1h30m"},
+		{uptime: 24 * time.Hour, want: "1d0h"},
+		{uptime: 50*time.Hour + 59*time.Minute, want: "2d2h"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uptime.String(), func(t *testing.T) {
+			if got := formatUptime(tt.uptime); got != tt.want {
+				t.Errorf("formatUptime(%v) = %q, want %q", tt.uptime, got, tt.want)
+			}
+		})
+	}
+}
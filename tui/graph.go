@@ -0,0 +1,218 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/syrm/c8s/dto"
+)
+
+var graphWindows = []time.Duration{time.Minute, 5 * time.Minute, 10 * time.Minute}
+
+type graphSeries struct {
+	name    string
+	samples []dto.MetricSample
+}
+
+type graphView struct {
+	root        *tview.Flex
+	cpu         *chart
+	memory      *chart
+	name        string
+	projectID   dto.ProjectID
+	containerID dto.ContainerID
+	windowIndex int
+	overlay     bool
+	containers  []dto.Container
+	history     dto.MetricHistory
+}
+
+func (t *Tui) openProjectGraph() {
+	project, ok := t.selectedProject()
+	if !ok {
+		return
+	}
+
+	table, view := t.projectTable()
+	t.openGraph(project.Name, project.ID, "", view, table)
+}
+
+func (t *Tui) openContainerGraph() {
+	container, ok := t.selectedContainer()
+	if !ok {
+		return
+	}
+
+	table, view := t.containerTable()
+	t.openGraph(container.Name, container.Project.ID, container.ID, view, table)
+}
+
+func (t *Tui) openGraph(name string, projectID dto.ProjectID, containerID dto.ContainerID, backView currentView, back tview.Primitive) {
+	gv := &graphView{
+		cpu: newChart(func(value float64) string {
+			return fmt.Sprintf("%.1f%%", value)
+		}),
+		memory:      newChart(formatBytes),
+		name:        name,
+		projectID:   projectID,
+		containerID: containerID,
+	}
+
+	gv.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(gv.cpu, 0, 1, true).
+		AddItem(gv.memory, 0, 1, false)
+
+	ctx, cancel := context.WithCancel(t.ctx)
+
+	gv.cpu.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			cancel()
+
+			t.currentViewLock.Lock()
+			t.currentView = backView
+			t.currentViewLock.Unlock()
+
+			t.setView(back)
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'w':
+				gv.windowIndex = (gv.windowIndex + 1) % len(graphWindows)
+				gv.update()
+				return nil
+			case 'o':
+				gv.overlay = !gv.overlay
+				gv.update()
+				return nil
+			}
+		}
+
+		return event
+	})
+
+	t.currentViewLock.Lock()
+	t.currentView = viewGraph
+	t.currentViewLock.Unlock()
+
+	t.setView(gv.root)
+	t.status.SetText("[::d]w time window  o overlay project containers  esc back")
+	gv.update()
+
+	go t.refreshGraph(ctx, gv)
+}
+
+func (t *Tui) refreshGraph(ctx context.Context, gv *graphView) {
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		containersResponse := make(chan []dto.Container)
+		t.requestData <- &RequestProject{
+			ProjectID: gv.projectID,
+			Response:  containersResponse,
+		}
+		containers := <-containersResponse
+
+		historyResponse := make(chan dto.MetricHistory)
+		t.requestData <- &RequestHistory{
+			ProjectID: gv.projectID,
+			Response:  historyResponse,
+		}
+		history := <-historyResponse
+
+		t.app.QueueUpdateDraw(func() {
+			if ctx.Err() != nil {
+				return
+			}
+
+			gv.containers = containers
+			gv.history = history
+			gv.update()
+		})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// update rebuilds the series of both charts, it must be called from the UI goroutine.
+func (gv *graphView) update() {
+	window := graphWindows[gv.windowIndex]
+	end := time.Now()
+
+	containers := slices.SortedFunc(slices.Values(gv.containers), func(a, b dto.Container) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	// The charted container comes first so it keeps the first colour when overlaid.
+	if index := slices.IndexFunc(containers, func(c dto.Container) bool { return c.ID == gv.containerID }); index > 0 {
+		selected := containers[index]
+		containers = slices.Insert(slices.Delete(containers, index, index+1), 0, selected)
+	}
+
+	var series []graphSeries
+	switch {
+	case gv.overlay:
+		for _, container := range containers {
+			series = append(series, graphSeries{name: container.Name, samples: gv.history[container.ID]})
+		}
+	case gv.containerID != "":
+		series = append(series, graphSeries{name: gv.name, samples: gv.history[gv.containerID]})
+	default:
+		containerIDs := make([]dto.ContainerID, 0, len(containers))
+		for _, container := range containers {
+			containerIDs = append(containerIDs, container.ID)
+		}
+
+		series = append(series, graphSeries{name: gv.name, samples: aggregateHistory(gv.history, containerIDs)})
+	}
+
+	gv.cpu.series = nil
+	gv.memory.series = nil
+	for index, s := range series {
+		color := chartColors[index%len(chartColors)]
+		gv.cpu.series = append(gv.cpu.series, chartSeries{name: s.name, color: color, points: windowPoints(s.samples, end.Add(-window), cpuSample)})
+		gv.memory.series = append(gv.memory.series, chartSeries{name: s.name, color: color, points: windowPoints(s.samples, end.Add(-window), memoryUsageSample)})
+	}
+
+	for _, c := range []*chart{gv.cpu, gv.memory} {
+		c.window = window
+		c.end = end
+	}
+
+	gv.cpu.SetTitle(fmt.Sprintf(" %s CPU │ last %s ", tview.Escape(gv.name), formatWindow(window)))
+	gv.memory.SetTitle(fmt.Sprintf(" %s memory │ last %s ", tview.Escape(gv.name), formatWindow(window)))
+}
+
+// formatWindow prints "5m" rather than "5m0s".
+func formatWindow(window time.Duration) string {
+	return strings.TrimSuffix(window.String(), "0s")
+}
+
+func memoryUsageSample(sample dto.MetricSample) float64 {
+	return sample.MemoryUsage
+}
+
+func windowPoints(samples []dto.MetricSample, start time.Time, value func(dto.MetricSample) float64) []chartPoint {
+	var points []chartPoint
+	for _, sample := range samples {
+		if sample.Time.Before(start) {
+			continue
+		}
+
+		points = append(points, chartPoint{time: sample.Time, value: value(sample)})
+	}
+
+	return points
+}
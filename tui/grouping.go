@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/syrm/c8s/dto"
+)
+
+// cycleGrouping switches to the next grouping, the label grouping is skipped until a label key is chosen with "B".
+func (t *Tui) cycleGrouping() {
+	grouping := t.grouping
+	grouping.Kind = (grouping.Kind + 1) % (dto.GroupByNetwork + 1)
+	if grouping.Kind == dto.GroupByLabel && grouping.Label == "" {
+		grouping.Kind++
+	}
+
+	t.setGrouping(grouping)
+}
+
+func (t *Tui) openLabelGrouping() {
+	input := tview.NewInputField().SetLabel("group by label: ").SetText(t.grouping.Label)
+
+	input.SetDoneFunc(func(key tcell.Key) {
+		t.layout.RemoveItem(input)
+		t.layout.AddItem(t.status, 1, 0, false)
+		table, _ := t.projectTable()
+		t.app.SetFocus(table)
+
+		if key != tcell.KeyEnter || input.GetText() == "" {
+			return
+		}
+
+		t.setGrouping(dto.Grouping{Kind: dto.GroupByLabel, Label: input.GetText()})
+	})
+
+	t.layout.RemoveItem(t.status)
+	t.layout.AddItem(input, 1, 0, true)
+	t.app.SetFocus(input)
+}
+
+// setGrouping empties the project table until the projects of the new grouping are fetched.
+func (t *Tui) setGrouping(grouping dto.Grouping) {
+	t.grouping = grouping
+
+	t.tableProjectDataLock.Lock()
+	t.tableProjectData = make(map[dto.ProjectID]dto.Project)
+	t.tableProjectDataLock.Unlock()
+	t.redrawTop()
+
+	status := fmt.Sprintf("grouped by %s", grouping.Kind)
+	if grouping.Kind == dto.GroupByLabel {
+		status += " " + tview.Escape(grouping.Label)
+	}
+	t.status.SetText(status)
+
+	draw := t.topDraw()
+	go func() {
+		t.requestData <- &RequestGrouping{Grouping: grouping}
+		t.refreshProjects(draw)
+	}()
+}
+
+func groupingTitle(grouping dto.Grouping) string {
+	switch grouping.Kind {
+	case dto.GroupByCompose:
+		return "Project"
+	case dto.GroupByStack:
+		return "Stack"
+	case dto.GroupByLabel:
+		return "Label " + grouping.Label
+	case dto.GroupByImage:
+		return "Image"
+	case dto.GroupByNetwork:
+		return "Network"
+	}
+
+	return grouping.Kind.String()
+}
@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/syrm/c8s/dto"
+)
+
+func (t *Tui) openContainerHealth() {
+	t.inspectSelectedContainer(func(container dto.Container, detail dto.ContainerDetail) func() {
+		return func() {
+			t.showContainerHealth(detail)
+		}
+	})
+}
+
+func (t *Tui) showContainerHealth(detail dto.ContainerDetail) {
+	focused := t.app.GetFocus()
+
+	text := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(formatHealthProbes(detail))
+	text.SetBorder(true).
+		SetTitle(" " + tview.Escape(strings.TrimPrefix(detail.Name, "/")) + " health ")
+
+	text.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc || event.Key() == tcell.KeyEnter {
+			t.pages.RemovePage(pageHealth)
+			t.app.SetFocus(focused)
+			return nil
+		}
+
+		return event
+	})
+
+	popup := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(text, 0, 3, true).
+			AddItem(nil, 0, 1, false), 0, 3, true).
+		AddItem(nil, 0, 1, false)
+
+	t.pages.AddPage(pageHealth, popup, true, true)
+	text.ScrollToEnd()
+}
+
+func formatHealthProbes(detail dto.ContainerDetail) string {
+	if detail.Health == "" {
+		return "[::d]no healthcheck configured"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "[::b]Status[::-] %s  [::b]Failing streak[::-] %d\n", detail.Health, detail.FailingStreak)
+
+	if len(detail.HealthProbes) == 0 {
+		b.WriteString("\n[::d]no probe run yet")
+	}
+
+	for _, probe := range detail.HealthProbes {
+		color := "green"
+		if probe.ExitCode != 0 {
+			color = "red"
+		}
+
+		fmt.Fprintf(&b, "\n[::b]%s[::-] [%s]exit %d[-] [::d]in %s[::-]\n",
+			probe.Start.Local().Format("15:04:05"),
+			color,
+			probe.ExitCode,
+			probe.End.Sub(probe.Start).Round(time.Millisecond),
+		)
+
+		if output := strings.TrimSpace(probe.Output); output != "" {
+			b.WriteString(tview.Escape(output) + "\n")
+		}
+	}
+
+	return b.String()
+}
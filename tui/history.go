@@ -0,0 +1,106 @@
+package tui
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/syrm/c8s/dto"
+)
+
+const sparklineSize = 20
+
+var sparklineBlocks = []rune("▁▂▃▄▅▆▇█")
+
+func (t *Tui) fetchHistory(projectID dto.ProjectID) {
+	response := make(chan dto.MetricHistory)
+	t.requestData <- &RequestHistory{
+		ProjectID: projectID,
+		Response:  response,
+	}
+
+	history := <-response
+
+	t.historyLock.Lock()
+	t.history = history
+	t.historyLock.Unlock()
+}
+
+func (t *Tui) containerSparkline(containerID dto.ContainerID) string {
+	t.historyLock.RLock()
+	samples := t.history[containerID]
+	t.historyLock.RUnlock()
+
+	return sparkline(sampleValues(samples, cpuSample), sparklineSize, 1)
+}
+
+func (t *Tui) projectSparkline(project dto.Project) string {
+	t.historyLock.RLock()
+	samples := aggregateHistory(t.history, slices.Collect(maps.Keys(project.ContainersState)))
+	t.historyLock.RUnlock()
+
+	return sparkline(sampleValues(samples, cpuSample), sparklineSize, 1)
+}
+
+func cpuSample(sample dto.MetricSample) float64 {
+	return sample.CPUPercentage
+}
+
+func sampleValues(samples []dto.MetricSample, value func(dto.MetricSample) float64) []float64 {
+	values := make([]float64, len(samples))
+	for index, sample := range samples {
+		values[index] = value(sample)
+	}
+
+	return values
+}
+
+// aggregateHistory sums the samples of several containers, aligned on their newest sample.
+func aggregateHistory(history dto.MetricHistory, containerIDs []dto.ContainerID) []dto.MetricSample {
+	size := 0
+	for _, containerID := range containerIDs {
+		size = max(size, len(history[containerID]))
+	}
+
+	aggregated := make([]dto.MetricSample, size)
+	for _, containerID := range containerIDs {
+		samples := history[containerID]
+		offset := size - len(samples)
+
+		for index, sample := range samples {
+			total := &aggregated[offset+index]
+			total.Time = sample.Time
+			total.CPUPercentage += sample.CPUPercentage
+			total.MemoryPercentage += sample.MemoryPercentage
+			total.MemoryUsage += sample.MemoryUsage
+			total.NetworkRxRate += sample.NetworkRxRate
+			total.NetworkTxRate += sample.NetworkTxRate
+			total.BlockReadRate += sample.BlockReadRate
+			total.BlockWriteRate += sample.BlockWriteRate
+		}
+	}
+
+	return aggregated
+}
+
+// sparkline renders the last width values, scaled on their maximum but never below floor so idle noise stays flat.
+func sparkline(values []float64, width int, floor float64) string {
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+
+	top := floor
+	for _, value := range values {
+		top = max(top, value)
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.Repeat(" ", width-len(values)))
+
+	for _, value := range values {
+		level := int(max(0, value) / top * float64(len(sparklineBlocks)-1))
+		b.WriteRune(sparklineBlocks[min(level, len(sparklineBlocks)-1)])
+	}
+
+	return b.String()
+}
@@ -0,0 +1,28 @@
+package tui
+
+import "testing"
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		width  int
+		floor  float64
+		want   string
+	}{
+		{name: "empty", width: 3, floor: 1, want: "   "},
+		{name: "padded on the left", values: []float64{0, 1}, width: 4, floor: 1, want: "  ▁█"},
+		{name: "scaled on the highest value", values: []float64{0, 50, 100}, width: 3, floor: 1, want: "▁▄█"},
+		{name: "floor keeps small values low", values: []float64{0, 0.5}, width: 2, floor: 1, want: "▁▄"},
+		{name: "negative values are clamped", values: []float64{-5, 2}, width: 2, floor: 1, want: "▁█"},
+		{name: "keeps the newest values", values: []float64{100, 0, 0, 100}, width: 2, floor: 1, want: "▁█"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sparkline(tt.values, tt.width, tt.floor); got != tt.want {
+				t.Errorf("sparkline(%v, %d, %v) = %q, want %q", tt.values, tt.width, tt.floor, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,514 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/syrm/c8s/dto"
+)
+
+const logBufferSize = 10000
+
+var logTailSizes = []string{"100", "500", "1000", "all"}
+
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;?]*[a-zA-Z]`)
+
+var logServiceColors = []string{"aqua", "yellow", "fuchsia", "lime", "blue", "orange", "teal", "pink", "olive", "purple"}
+
+type logRequestFunc func(ctx context.Context, tail string, follow bool, lines chan dto.LogLine, response chan error) RequestData
+
+type logView struct {
+	root       *tview.Flex
+	text       *tview.TextView
+	name       string
+	lines      *ring[dto.LogLine]
+	timestamps bool
+	autoscroll bool
+	follow     bool
+	tailIndex  int
+	request    logRequestFunc
+	cancel     context.CancelFunc
+
+	// Merged project logs prefix each line with its service, which can be hidden.
+	withServices bool
+	services     []string
+	hidden       map[string]bool
+
+	// Matches of search are highlighted as regions named after their index.
+	search       *regexp.Regexp
+	filter       bool
+	matches      int
+	currentMatch int
+}
+
+func (t *Tui) openContainerLogs() {
+	container, ok := t.selectedContainer()
+	if !ok {
+		return
+	}
+
+	t.openLogs(container.Name, false, func(ctx context.Context, tail string, follow bool, lines chan dto.LogLine, response chan error) RequestData {
+		return &RequestContainerLogs{
+			Ctx:         ctx,
+			ContainerID: container.ID,
+			Tail:        tail,
+			Follow:      follow,
+			Lines:       lines,
+			Response:    response,
+		}
+	})
+}
+
+func (t *Tui) openProjectLogs() {
+	project, ok := t.selectedProject()
+	if !ok {
+		return
+	}
+
+	t.openLogs(project.Name, true, func(ctx context.Context, tail string, follow bool, lines chan dto.LogLine, response chan error) RequestData {
+		return &RequestProjectLogs{
+			Ctx:       ctx,
+			ProjectID: project.ID,
+			Tail:      tail,
+			Follow:    follow,
+			Lines:     lines,
+			Response:  response,
+		}
+	})
+}
+
+func (t *Tui) openLogs(name string, withServices bool, request logRequestFunc) {
+	text := tview.NewTextView().
+		SetDynamicColors(true).
+		SetRegions(true).
+		SetMaxLines(logBufferSize).
+		SetWrap(true)
+	text.SetBorder(true)
+
+	lv := &logView{
+		root:       tview.NewFlex().SetDirection(tview.FlexRow).AddItem(text, 0, 1, true),
+		text:       text,
+		name:       name,
+		lines:      newRing[dto.LogLine](logBufferSize),
+		autoscroll: true,
+		follow:     true,
+		request:    request,
+		hidden:     make(map[string]bool),
+
+		withServices: withServices,
+	}
+
+	text.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			if lv.search != nil {
+				lv.setSearch(nil)
+				return nil
+			}
+
+			t.closeLogs()
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case '/':
+				t.openLogSearch()
+				return nil
+			case 'n':
+				lv.jumpToMatch(1)
+				return nil
+			case 'N':
+				lv.jumpToMatch(-1)
+				return nil
+			case 'F':
+				lv.filter = !lv.filter
+				lv.redraw()
+				return nil
+			case 't':
+				lv.timestamps = !lv.timestamps
+				lv.redraw()
+				return nil
+			case 'f':
+				lv.follow = !lv.follow
+				t.startLogs()
+				return nil
+			case 'T':
+				lv.tailIndex = (lv.tailIndex + 1) % len(logTailSizes)
+				t.startLogs()
+				return nil
+			case ' ':
+				lv.setAutoscroll(!lv.autoscroll)
+				return nil
+			case '1', '2', '3', '4', '5', '6', '7', '8', '9':
+				lv.toggleService(int(event.Rune() - '1'))
+				return nil
+			}
+		}
+
+		return event
+	})
+
+	t.logs = lv
+
+	t.currentViewLock.Lock()
+	t.currentView = viewLogs
+	t.currentViewLock.Unlock()
+
+	t.setView(lv.root)
+	help := "[::d]/ search  n/N next/previous  F filter  t timestamps  f follow  T tail size  space pause autoscroll  esc back"
+	if withServices {
+		help += "  1-9 toggle service"
+	}
+
+	t.status.SetText(help)
+	t.startLogs()
+}
+
+func (t *Tui) closeLogs() {
+	withServices := t.logs.withServices
+	t.logs.cancel()
+	t.logs = nil
+
+	// Project logs are opened from the project list, container logs from the container table.
+	if withServices {
+		t.showProjectTable()
+		return
+	}
+
+	t.showContainerTable()
+}
+
+// startLogs (re)starts the stream of the current log view, it must be called from the UI goroutine.
+func (t *Tui) startLogs() {
+	lv := t.logs
+	if lv.cancel != nil {
+		lv.cancel()
+	}
+
+	ctx, cancel := context.WithCancel(t.ctx)
+	lv.cancel = cancel
+	lv.lines.Clear()
+	lv.redraw()
+
+	lines := make(chan dto.LogLine)
+	response := make(chan error)
+	request := lv.request(ctx, logTailSizes[lv.tailIndex], lv.follow, lines, response)
+
+	go func() {
+		t.requestData <- request
+
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+
+		var pending []dto.LogLine
+		flush := func() {
+			if len(pending) == 0 {
+				return
+			}
+
+			batch := pending
+			pending = nil
+
+			t.app.QueueUpdateDraw(func() {
+				// The stream may have been restarted or closed since the batch was read.
+				if ctx.Err() != nil {
+					return
+				}
+
+				lv.append(batch)
+			})
+		}
+
+	read:
+		for {
+			select {
+			case line, ok := <-lines:
+				if !ok {
+					break read
+				}
+
+				pending = append(pending, line)
+			case <-ticker.C:
+				flush()
+			}
+		}
+
+		flush()
+
+		if err := <-response; err != nil && ctx.Err() == nil {
+			t.setStatus(fmt.Sprintf("[red]logs of %s failed: %s", lv.name, tview.Escape(err.Error())))
+		}
+	}()
+}
+
+func (t *Tui) openLogSearch() {
+	lv := t.logs
+
+	input := tview.NewInputField().SetLabel("/")
+	if lv.search != nil {
+		input.SetText(lv.search.String())
+	}
+
+	input.SetDoneFunc(func(key tcell.Key) {
+		lv.root.RemoveItem(input)
+		t.app.SetFocus(lv.text)
+
+		if key != tcell.KeyEnter {
+			return
+		}
+
+		if input.GetText() == "" {
+			lv.setSearch(nil)
+			return
+		}
+
+		search, err := regexp.Compile(input.GetText())
+		if err != nil {
+			t.status.SetText(fmt.Sprintf("[red]invalid search: %s", tview.Escape(err.Error())))
+			return
+		}
+
+		lv.setSearch(search)
+		lv.jumpToMatch(1)
+	})
+
+	lv.root.AddItem(input, 1, 0, true)
+	t.app.SetFocus(input)
+}
+
+func (lv *logView) append(lines []dto.LogLine) {
+	for _, line := range lines {
+		lv.lines.Push(line)
+
+		if lv.withServices && !slices.Contains(lv.services, line.Service) {
+			lv.services = append(lv.services, line.Service)
+			slices.Sort(lv.services)
+			lv.updateTitle()
+		}
+
+		if !lv.visible(line) {
+			continue
+		}
+
+		fmt.Fprintln(lv.text, lv.format(line))
+	}
+
+	if lv.autoscroll {
+		lv.text.ScrollToEnd()
+	}
+}
+
+func (lv *logView) redraw() {
+	lv.updateTitle()
+
+	lv.matches = 0
+	lv.currentMatch = -1
+
+	var b strings.Builder
+	for line := range lv.lines.All() {
+		if !lv.visible(line) {
+			continue
+		}
+
+		b.WriteString(lv.format(line))
+		b.WriteByte('\n')
+	}
+
+	lv.text.SetText(b.String())
+
+	if lv.autoscroll {
+		lv.text.ScrollToEnd()
+	}
+}
+
+func (lv *logView) setAutoscroll(autoscroll bool) {
+	lv.autoscroll = autoscroll
+
+	if autoscroll {
+		lv.text.ScrollToEnd()
+	} else {
+		// Scrolling to the current position stops the TextView from tracking the end.
+		row, _ := lv.text.GetScrollOffset()
+		lv.text.ScrollTo(row, 0)
+	}
+
+	lv.updateTitle()
+}
+
+func (lv *logView) visible(line dto.LogLine) bool {
+	if lv.hidden[line.Service] {
+		return false
+	}
+
+	if lv.filter && lv.search != nil {
+		return lv.search.MatchString(ansiEscape.ReplaceAllString(line.Text, ""))
+	}
+
+	return true
+}
+
+func (lv *logView) setSearch(search *regexp.Regexp) {
+	lv.search = search
+	if search == nil {
+		lv.filter = false
+	}
+
+	lv.redraw()
+}
+
+// jumpToMatch highlights the next match in the given direction, wrapping around.
+// The oldest lines are dropped once the buffer is full, so are their regions: only the last matches still exist.
+func (lv *logView) jumpToMatch(direction int) {
+	held := lv.heldMatches()
+	if held == 0 {
+		return
+	}
+
+	first := lv.matches - held
+	index := lv.currentMatch - first
+	if index < 0 || index >= held {
+		index = 0
+		if direction > 0 {
+			index = -1
+		}
+	}
+
+	lv.currentMatch = first + (index+direction+held)%held
+	lv.setAutoscroll(false)
+	lv.text.Highlight(strconv.Itoa(lv.currentMatch)).ScrollToHighlight()
+}
+
+// heldMatches counts the matches of the visible lines held by the buffer, the way highlight numbers them.
+func (lv *logView) heldMatches() int {
+	if lv.search == nil {
+		return 0
+	}
+
+	matches := 0
+	for line := range lv.lines.All() {
+		if !lv.visible(line) {
+			continue
+		}
+
+		for _, location := range lv.search.FindAllStringIndex(ansiEscape.ReplaceAllString(line.Text, ""), -1) {
+			if location[0] != location[1] {
+				matches++
+			}
+		}
+	}
+
+	return min(matches, lv.matches)
+}
+
+func (lv *logView) toggleService(index int) {
+	if !lv.withServices || index >= len(lv.services) {
+		return
+	}
+
+	service := lv.services[index]
+	lv.hidden[service] = !lv.hidden[service]
+	lv.redraw()
+}
+
+func (lv *logView) updateTitle() {
+	follow := "off"
+	if lv.follow {
+		follow = "on"
+	}
+
+	title := fmt.Sprintf(" %s logs │ follow %s │ tail %s ", tview.Escape(lv.name), follow, logTailSizes[lv.tailIndex])
+	if !lv.autoscroll {
+		title += "│ [yellow]paused[-] "
+	}
+
+	if lv.search != nil {
+		title += fmt.Sprintf("│ /%s ", tview.Escape(lv.search.String()))
+		if lv.filter {
+			title += "[yellow]filtered[-] "
+		}
+	}
+
+	for index, service := range lv.services {
+		if index >= 9 {
+			break
+		}
+
+		if lv.hidden[service] {
+			title += fmt.Sprintf("[gray::s]%d:%s[-::-] ", index+1, tview.Escape(service))
+			continue
+		}
+
+		title += fmt.Sprintf("[%s]%d:%s[-] ", serviceColor(service), index+1, tview.Escape(service))
+	}
+
+	lv.text.SetTitle(title)
+}
+
+func (lv *logView) format(line dto.LogLine) string {
+	var b strings.Builder
+
+	if lv.timestamps && !line.Time.IsZero() {
+		b.WriteString("[gray]")
+		b.WriteString(line.Time.Local().Format("2006-01-02 15:04:05.000"))
+		b.WriteString("[-] ")
+	}
+
+	if lv.withServices {
+		fmt.Fprintf(&b, "[%s]%s |[-] ", serviceColor(line.Service), tview.Escape(line.Service))
+	}
+
+	text := lv.highlight(line.Text)
+	if line.Stream == dto.LogStreamStderr {
+		text = "[red]" + text + "[-]"
+	}
+
+	b.WriteString(text)
+
+	return b.String()
+}
+
+// serviceColor gives a service the same colour on every redraw, whatever the order lines arrive in.
+func serviceColor(service string) string {
+	h := fnv.New32a()
+	h.Write([]byte(service))
+
+	return logServiceColors[h.Sum32()%uint32(len(logServiceColors))]
+}
+
+func (lv *logView) highlight(raw string) string {
+	if lv.search == nil {
+		return tview.TranslateANSI(tview.Escape(raw))
+	}
+
+	// ANSI colours are dropped on searched lines so matches are found on the visible text.
+	raw = ansiEscape.ReplaceAllString(raw, "")
+	locations := lv.search.FindAllStringIndex(raw, -1)
+	if len(locations) == 0 {
+		return tview.Escape(raw)
+	}
+
+	var b strings.Builder
+	previous := 0
+	for _, location := range locations {
+		if location[0] == location[1] {
+			continue
+		}
+
+		b.WriteString(tview.Escape(raw[previous:location[0]]))
+		fmt.Fprintf(&b, `["%d"][:yellow]%s[:-][""]`, lv.matches, tview.Escape(raw[location[0]:location[1]]))
+		previous = location[1]
+		lv.matches++
+	}
+
+	b.WriteString(tview.Escape(raw[previous:]))
+
+	return b.String()
+}
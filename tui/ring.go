@@ -0,0 +1,42 @@
+package tui
+
+import "iter"
+
+// ring keeps the last values pushed, overwriting the oldest once full.
+type ring[T any] struct {
+	values []T
+	start  int
+	size   int
+}
+
+func newRing[T any](capacity int) *ring[T] {
+	return &ring[T]{values: make([]T, capacity)}
+}
+
+func (r *ring[T]) Push(value T) {
+	if r.size < len(r.values) {
+		r.values[(r.start+r.size)%len(r.values)] = value
+		r.size++
+		return
+	}
+
+	r.values[r.start] = value
+	r.start = (r.start + 1) % len(r.values)
+}
+
+func (r *ring[T]) Clear() {
+	clear(r.values)
+	r.start = 0
+	r.size = 0
+}
+
+// All iterates from the oldest to the newest value.
+func (r *ring[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range r.size {
+			if !yield(r.values[(r.start+i)%len(r.values)]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRing(t *testing.T) {
+	tests := []struct {
+		name   string
+		pushed []int
+		want   []int
+	}{
+		{name: "empty", want: nil},
+		{name: "not full", pushed: []int{1, 2}, want: []int{1, 2}},
+		{name: "full", pushed: []int{1, 2, 3}, want: []int{1, 2, 3}},
+		{name: "overwrites the oldest", pushed: []int{1, 2, 3, 4, 5}, want: []int{3, 4, 5}},
+		{name: "wraps several times", pushed: []int{1, 2, 3, 4, 5, 6, 7}, want: []int{5, 6, 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRing[int](3)
+			for _, value := range tt.pushed {
+				r.Push(value)
+			}
+
+			if got := slices.Collect(r.All()); !slices.Equal(got, tt.want) {
+				t.Errorf("All() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRingClear(t *testing.T) {
+	r := newRing[int](3)
+	for value := range 5 {
+		r.Push(value)
+	}
+
+	r.Clear()
+	if got := slices.Collect(r.All()); len(got) != 0 {
+		t.Fatalf("All() after Clear() = %v, want nothing", got)
+	}
+
+	r.Push(10)
+	if got := slices.Collect(r.All()); !slices.Equal(got, []int{10}) {
+		t.Errorf("All() = %v, want [10]", got)
+	}
+}
+
+func TestRingStopsIterating(t *testing.T) {
+	r := newRing[int](3)
+	for value := range 4 {
+		r.Push(value)
+	}
+
+	var got []int
+	for value := range r.All() {
+		got = append(got, value)
+		break
+	}
+
+	if !slices.Equal(got, []int{1}) {
+		t.Errorf("first value = %v, want [1]", got)
+	}
+}
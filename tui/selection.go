@@ -0,0 +1,20 @@
+package tui
+
+import "github.com/rivo/tview"
+
+func selectedReference[ID comparable](table *tview.Table) (ID, bool) {
+	rowIndex, _ := table.GetSelection()
+	id, ok := table.GetCell(rowIndex, 0).GetReference().(ID)
+
+	return id, ok
+}
+
+// selectReference moves the selection to the row referencing id, the selected row index is kept when id is gone.
+func selectReference[ID comparable](table *tview.Table, id ID) {
+	for rowIndex := range table.GetRowCount() {
+		if reference, ok := table.GetCell(rowIndex, 0).GetReference().(ID); ok && reference == id {
+			table.Select(rowIndex, 0)
+			return
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rivo/tview"
+
+	"github.com/syrm/c8s/dto"
+)
+
+const splitMinWidth = 180
+
+const (
+	previewTail     = "50"
+	previewMaxLines = 500
+)
+
+type logPreview struct {
+	text        *tview.TextView
+	enabled     bool
+	containerID dto.ContainerID
+	cancel      context.CancelFunc
+}
+
+func newLogPreview() *logPreview {
+	text := tview.NewTextView().
+		SetDynamicColors(true).
+		SetMaxLines(previewMaxLines)
+	text.SetBorder(true)
+
+	return &logPreview{text: text}
+}
+
+// watchWidth is called before every draw, the layout can't be changed while drawing so the switch is queued.
+func (t *Tui) watchWidth(width int) {
+	wide := width >= splitMinWidth
+	if t.wide.Load() == wide {
+		return
+	}
+
+	t.app.QueueUpdateDraw(func() {
+		t.setWide(wide)
+	})
+}
+
+func (t *Tui) setWide(wide bool) {
+	if t.wide.Swap(wide) == wide {
+		return
+	}
+
+	// The layout is only rebuilt when a table has the focus, so an open input isn't lost.
+	if t.app.GetFocus() == t.view {
+		t.setView(t.view)
+	}
+}
+
+// followProjectSelection is called while the project table is drawn, it must not take t.tableProjectDataLock.
+func (t *Tui) followProjectSelection() {
+	if !t.wide.Load() || t.viewMode != viewModeProjects {
+		return
+	}
+
+	projectID, ok := selectedReference[dto.ProjectID](t.tableProject)
+	if !ok || projectID == t.targetedProjectID() {
+		return
+	}
+
+	t.setTargetedProjectID(projectID)
+	t.containerFilter = ""
+	t.tableContainer.Clear()
+	t.tableContainer.Select(0, 0)
+
+	go t.refreshContainers()
+}
+
+func (t *Tui) togglePreview() {
+	if !t.splitShown {
+		return
+	}
+
+	t.preview.enabled = !t.preview.enabled
+
+	t.splitContainers.RemoveItem(t.preview.text)
+	if t.preview.enabled {
+		t.splitContainers.AddItem(t.preview.text, 0, 1, false)
+	}
+
+	t.updatePreview()
+}
+
+func (t *Tui) updatePreview() {
+	p := t.preview
+	if p == nil {
+		return
+	}
+
+	var containerID dto.ContainerID
+	if t.splitShown && p.enabled {
+		containerID, _ = selectedReference[dto.ContainerID](t.tableContainer)
+	}
+
+	if containerID == p.containerID {
+		return
+	}
+
+	if p.cancel != nil {
+		p.cancel()
+		p.cancel = nil
+	}
+
+	p.containerID = containerID
+	p.text.Clear()
+	p.text.SetTitle("")
+	if containerID == "" {
+		return
+	}
+
+	t.tableContainerDataLock.RLock()
+	name := t.tableContainerData[containerID].Name
+	t.tableContainerDataLock.RUnlock()
+
+	p.text.SetTitle(" " + tview.Escape(name) + " logs ")
+
+	ctx, cancel := context.WithCancel(t.ctx)
+	p.cancel = cancel
+
+	lines := make(chan dto.LogLine)
+	response := make(chan error)
+
+	go func() {
+		t.requestData <- &RequestContainerLogs{
+			Ctx:         ctx,
+			ContainerID: containerID,
+			Tail:        previewTail,
+			Follow:      true,
+			Lines:       lines,
+			Response:    response,
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+
+		var pending []dto.LogLine
+		flush := func() {
+			if len(pending) == 0 {
+				return
+			}
+
+			batch := pending
+			pending = nil
+
+			t.app.QueueUpdateDraw(func() {
+				if ctx.Err() != nil {
+					return
+				}
+
+				for _, line := range batch {
+					fmt.Fprintln(p.text, tview.TranslateANSI(tview.Escape(line.Text)))
+				}
+				p.text.ScrollToEnd()
+			})
+		}
+
+	read:
+		for {
+			select {
+			case line, ok := <-lines:
+				if !ok {
+					break read
+				}
+
+				pending = append(pending, line)
+			case <-ticker.C:
+				flush()
+			}
+		}
+
+		flush()
+
+		if err := <-response; err != nil && ctx.Err() == nil {
+			t.setStatus(fmt.Sprintf("[red]logs of %s failed: %s", name, tview.Escape(err.Error())))
+		}
+	}()
+}
@@ -0,0 +1,241 @@
+package tui
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/syrm/c8s/dto"
+)
+
+var timelineActionColors = map[string]string{
+	"start":                    "green",
+	"restart":                  "green",
+	"health_status: healthy":   "green",
+	"die":                      "yellow",
+	"kill":                     "yellow",
+	"oom":                      "red",
+	"health_status: unhealthy": "red",
+	"destroy":                  "gray",
+}
+
+type timelineView struct {
+	table       *tview.Table
+	name        string
+	projectID   dto.ProjectID
+	containerID dto.ContainerID
+	// global shows the events of every project, whatever the timeline was opened from.
+	global atomic.Bool
+	filter string
+	events []dto.Event
+}
+
+// timelineEventKey references the rows, so the selection stays on the same event while newer ones are added.
+type timelineEventKey struct {
+	time      int64
+	eventType string
+	name      string
+	action    string
+}
+
+// openProjectTimeline falls back to the events of every project when no project is selected.
+func (t *Tui) openProjectTimeline() {
+	table, view := t.projectTable()
+
+	project, ok := t.selectedProject()
+	if !ok {
+		t.openTimeline("all", "", "", view, table)
+		return
+	}
+
+	t.openTimeline(project.Name, project.ID, "", view, table)
+}
+
+func (t *Tui) openContainerTimeline() {
+	container, ok := t.selectedContainer()
+	if !ok {
+		return
+	}
+
+	table, view := t.containerTable()
+	t.openTimeline(container.Name, container.Project.ID, container.ID, view, table)
+}
+
+func (t *Tui) openTimeline(name string, projectID dto.ProjectID, containerID dto.ContainerID, backView currentView, back tview.Primitive) {
+	tv := &timelineView{
+		table:       tview.NewTable().SetSelectable(true, false).SetFixed(1, 0),
+		name:        name,
+		projectID:   projectID,
+		containerID: containerID,
+	}
+	tv.table.SetBorder(true)
+
+	ctx, cancel := context.WithCancel(t.ctx)
+
+	tv.table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			cancel()
+
+			t.currentViewLock.Lock()
+			t.currentView = backView
+			t.currentViewLock.Unlock()
+
+			t.setView(back)
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case '/':
+				t.openTableFilter(tv.table, &tv.filter, tv.draw)
+				return nil
+			case 'a':
+				tv.global.Store(!tv.global.Load())
+				go t.refreshTimeline(ctx, tv)
+				return nil
+			}
+		}
+
+		return event
+	})
+
+	t.currentViewLock.Lock()
+	t.currentView = viewTimeline
+	t.currentViewLock.Unlock()
+
+	t.setView(tv.table)
+	t.status.SetText("[::d]/ filter  a all projects  esc back")
+	tv.draw()
+
+	go func() {
+		ticker := time.NewTicker(2 * time.Second)
+		defer ticker.Stop()
+
+		for {
+			t.refreshTimeline(ctx, tv)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (t *Tui) refreshTimeline(ctx context.Context, tv *timelineView) {
+	request := &RequestTimeline{
+		ProjectID:   tv.projectID,
+		ContainerID: tv.containerID,
+		Response:    make(chan []dto.Event),
+	}
+	if tv.global.Load() {
+		request.ProjectID = ""
+		request.ContainerID = ""
+	}
+
+	t.requestData <- request
+	events := <-request.Response
+
+	t.app.QueueUpdateDraw(func() {
+		if ctx.Err() != nil {
+			return
+		}
+
+		tv.events = events
+		tv.draw()
+	})
+}
+
+// draw lists the newest events first, it must be called from the UI goroutine.
+func (tv *timelineView) draw() {
+	global := tv.global.Load()
+	selected, selectedOk := selectedReference[timelineEventKey](tv.table)
+
+	tv.table.Clear()
+	for position, title := range []string{"Time", "Project", "Type", "Name", "Event", "Attributes"} {
+		tv.table.SetCell(0, position, tview.NewTableCell("[::b]"+title).SetSelectable(false).SetExpansion(1))
+	}
+	tv.table.GetCell(0, 5).SetExpansion(4)
+
+	row := 1
+	for _, event := range slices.Backward(tv.events) {
+		attributes := formatEventAttributes(event.Attributes)
+		if !eventMatchesFilter(tv.filter, event, attributes) {
+			continue
+		}
+
+		color, ok := timelineActionColors[event.Action]
+		if event.Action == "die" && event.Attributes["exitCode"] != "0" {
+			color, ok = "red", true
+		}
+
+		action := tview.Escape(event.Action)
+		if ok {
+			action = "[" + color + "]" + action + "[-]"
+		}
+
+		key := timelineEventKey{time: event.Time.UnixNano(), eventType: event.Type, name: event.Name, action: event.Action}
+		tv.table.SetCell(row, 0, tview.NewTableCell(formatEventTime(event.Time)).SetReference(key))
+		tv.table.SetCell(row, 1, tview.NewTableCell(tview.Escape(eventProjects(event))))
+		tv.table.SetCell(row, 2, tview.NewTableCell("[::d]"+event.Type))
+		tv.table.SetCell(row, 3, tview.NewTableCell(tview.Escape(event.Name)))
+		tv.table.SetCell(row, 4, tview.NewTableCell(action))
+		tv.table.SetCell(row, 5, tview.NewTableCell(tview.Escape(attributes)))
+		row++
+	}
+
+	if selectedOk {
+		selectReference(tv.table, selected)
+	}
+
+	title := " " + tview.Escape(tv.name) + " events "
+	if global {
+		title = " all events "
+	}
+
+	tv.table.SetTitle(title + filterTitle(tv.filter))
+}
+
+func formatEventAttributes(attributes map[string]string) string {
+	var parts []string
+	for _, name := range slices.Sorted(maps.Keys(attributes)) {
+		parts = append(parts, name+"="+attributes[name])
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// formatEventTime only shows the date of the events of the previous days.
+func formatEventTime(at time.Time) string {
+	at = at.Local()
+	if year, month, day := time.Now().Date(); at.Year() == year && at.Month() == month && at.Day() == day {
+		return at.Format("15:04:05.000")
+	}
+
+	return at.Format("2006-01-02 15:04:05.000")
+}
+
+func eventProjects(event dto.Event) string {
+	var names []string
+	for _, project := range event.Projects {
+		names = append(names, project.Name)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func eventMatchesFilter(filter string, event dto.Event, attributes string) bool {
+	for _, text := range []string{eventProjects(event), event.Type, event.Name, event.Action} {
+		if _, ok := fuzzyMatch(filter, text); ok {
+			return true
+		}
+	}
+
+	return strings.Contains(strings.ToLower(attributes), strings.ToLower(filter))
+}
@@ -0,0 +1,201 @@
+package tui
+
+import (
+	"slices"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/syrm/c8s/dto"
+)
+
+// treeContainerRef references a container row of the tree, a container can be listed under several projects.
+type treeContainerRef struct {
+	projectID   dto.ProjectID
+	containerID dto.ContainerID
+}
+
+// treeContainerColumns maps each project column to the container column displayed below it in the tree, -1 when there is none.
+var treeContainerColumns = func() []int {
+	mapping := make([]int, len(projectColumns))
+	for index, projectColumn := range projectColumns {
+		mapping[index] = slices.IndexFunc(containerColumns, func(c column[dto.Container]) bool {
+			return c.title == projectColumn.title
+		})
+	}
+
+	// The project name and the service name are both the first column.
+	mapping[0] = 0
+
+	// The running containers count of a project is detailed by the state of each container.
+	mapping[slices.IndexFunc(projectColumns, func(c column[dto.Project]) bool { return c.title == "Cont." })] =
+		slices.IndexFunc(containerColumns, func(c column[dto.Container]) bool { return c.title == "State" })
+
+	return mapping
+}()
+
+func (t *Tui) redrawTree() {
+	t.tableProjectDataLock.RLock()
+	defer t.tableProjectDataLock.RUnlock()
+
+	t.drawTree()
+}
+
+// drawTree lists the projects, each expanded project followed by its containers, t.tableProjectDataLock must be held.
+func (t *Tui) drawTree() {
+	var projects []dto.Project
+	for _, project := range t.tableProjectData {
+		if projectMatchesFilter(t.treeFilter, project) {
+			projects = append(projects, project)
+		}
+	}
+
+	sortRows(t, projects, projectColumns, t.treeSort)
+
+	// Containers are sorted on the same metric as projects, by name when the column is only a project one.
+	containerSort := sortState{column: treeContainerColumns[t.treeSort.column], descending: t.treeSort.descending}
+	if containerSort.column < 0 || containerColumns[containerSort.column].compare == nil {
+		containerSort = sortState{}
+	}
+
+	selected, hasSelection := selectedReference[any](t.tableTree)
+	t.tableTree.Clear()
+	renderHeader(t, t.tableTree, projectColumns, t.treeSort, groupingTitle(t.grouping))
+
+	row := 1
+	for _, project := range projects {
+		expanded := t.treeExpanded[project.ID]
+
+		arrow := "▸ "
+		if expanded {
+			arrow = "▾ "
+		}
+
+		renderRow(t, t.tableTree, projectColumns, row, project, project.ID)
+		name, _ := fuzzyMatch(t.treeFilter, project.Name)
+		cell := t.tableTree.GetCell(row, 0)
+		cell.SetText(arrow + highlightMatches(project.Name, name, cell.Color))
+		row++
+
+		if !expanded {
+			continue
+		}
+
+		containers := slices.Clone(project.Containers)
+		sortRows(t, containers, containerColumns, containerSort)
+
+		for index, container := range containers {
+			branch := "  ├ "
+			if index == len(containers)-1 {
+				branch = "  └ "
+			}
+
+			for position, columnIndex := range visibleColumns(t, projectColumns) {
+				cell := tview.NewTableCell("")
+				if containerIndex := treeContainerColumns[columnIndex]; containerIndex >= 0 {
+					cell = containerColumns[containerIndex].cell(t, container).SetAlign(containerColumns[containerIndex].align)
+				}
+
+				t.tableTree.SetCell(row, position, cell)
+			}
+
+			t.tableTree.GetCell(row, 0).
+				SetText("[::d]" + branch + "[::-]" + tview.Escape(container.Service)).
+				SetReference(treeContainerRef{projectID: project.ID, containerID: container.ID})
+			row++
+		}
+	}
+
+	if hasSelection {
+		selectReference(t.tableTree, selected)
+	}
+
+	t.tableTree.SetTitle(filterTitle(t.treeFilter))
+}
+
+// setTreeExpanded expands or collapses the project of the selected row, the selection moves to the project row.
+func (t *Tui) setTreeExpanded(expanded bool) {
+	projectID, ok := selectedReference[dto.ProjectID](t.tableTree)
+	if !ok {
+		container, isContainer := selectedReference[treeContainerRef](t.tableTree)
+		if !isContainer {
+			return
+		}
+
+		projectID = container.projectID
+	}
+
+	t.treeExpanded[projectID] = expanded
+	t.redrawTree()
+	selectReference(t.tableTree, projectID)
+}
+
+func (t *Tui) setTreeExpandedAll(expanded bool) {
+	t.tableProjectDataLock.RLock()
+	for projectID := range t.tableProjectData {
+		t.treeExpanded[projectID] = expanded
+	}
+	t.tableProjectDataLock.RUnlock()
+
+	// Collapsed projects are forgotten so the ones appearing later start collapsed too.
+	if !expanded {
+		clear(t.treeExpanded)
+	}
+
+	t.redrawTree()
+}
+
+func (t *Tui) treeKeys(event *tcell.EventKey) *tcell.EventKey {
+	switch {
+	case event.Key() == tcell.KeyRight:
+		t.setTreeExpanded(true)
+		return nil
+	case event.Key() == tcell.KeyLeft:
+		t.setTreeExpanded(false)
+		return nil
+	case event.Key() == tcell.KeyRune && event.Rune() == 'v':
+		t.cycleViewMode()
+		return nil
+	case event.Key() == tcell.KeyRune && event.Rune() == '+':
+		t.setTreeExpandedAll(true)
+		return nil
+	case event.Key() == tcell.KeyRune && event.Rune() == '-':
+		t.setTreeExpandedAll(false)
+		return nil
+	}
+
+	sortKeys := func(key rune) {
+		t.treeSort = cycleSort(t, projectColumns, t.treeSort, key)
+	}
+
+	if projectID, ok := selectedReference[dto.ProjectID](t.tableTree); ok {
+		if event.Key() == tcell.KeyEnter {
+			t.treeExpanded[projectID] = !t.treeExpanded[projectID]
+			t.redrawTree()
+			return nil
+		}
+
+		return t.projectKeys(event, t.tableTree, sortKeys, &t.treeFilter, t.redrawTree)
+	}
+
+	return t.containerKeys(event, t.tableTree, sortKeys, &t.treeFilter, t.redrawTree)
+}
+
+func (t *Tui) selectedTreeContainer() (dto.Container, bool) {
+	ref, ok := selectedReference[treeContainerRef](t.tableTree)
+	if !ok {
+		return dto.Container{}, false
+	}
+
+	t.tableProjectDataLock.RLock()
+	defer t.tableProjectDataLock.RUnlock()
+
+	index := slices.IndexFunc(t.tableProjectData[ref.projectID].Containers, func(c dto.Container) bool {
+		return c.ID == ref.containerID
+	})
+	if index < 0 {
+		return dto.Container{}, false
+	}
+
+	return t.tableProjectData[ref.projectID].Containers[index], true
+}
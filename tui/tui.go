@@ -4,11 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"maps"
 	"os"
-	"slices"
-	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/syrm/c8s/dto"
@@ -22,6 +20,12 @@ type currentView int
 const (
 	viewProjectList currentView = iota
 	viewProject
+	viewLogs
+	viewDetail
+	viewGraph
+	viewContainerList
+	viewProjectTree
+	viewTimeline
 )
 
 type RequestData interface {
@@ -41,22 +45,148 @@ type RequestProject struct {
 
 func (p *RequestProject) isRequestData() {}
 
+type RequestContainerAction struct {
+	ContainerID dto.ContainerID
+	Action      ContainerAction
+	Response    chan error
+}
+
+func (p *RequestContainerAction) isRequestData() {}
+
+// RequestProjectAction acts on ContainerIDs, the containers of ProjectID confirmed by the user.
+type RequestProjectAction struct {
+	ProjectID    dto.ProjectID
+	ContainerIDs []dto.ContainerID
+	Action       ContainerAction
+	Progress     chan ProjectActionProgress
+	Response     chan error
+}
+
+func (p *RequestProjectAction) isRequestData() {}
+
+// RequestContainerLogs streams log lines until Ctx is done, or until the end of the logs when not following.
+// Lines is closed before the final error is sent on Response.
+type RequestContainerLogs struct {
+	Ctx         context.Context
+	ContainerID dto.ContainerID
+	Tail        string
+	Follow      bool
+	Lines       chan dto.LogLine
+	Response    chan error
+}
+
+func (p *RequestContainerLogs) isRequestData() {}
+
+// RequestProjectLogs merges the log streams of every container of a project, ordered by time.
+// Lines is closed before the final error is sent on Response.
+type RequestProjectLogs struct {
+	Ctx       context.Context
+	ProjectID dto.ProjectID
+	Tail      string
+	Follow    bool
+	Lines     chan dto.LogLine
+	Response  chan error
+}
+
+func (p *RequestProjectLogs) isRequestData() {}
+
+// RequestContainerExec runs an interactive command on the terminal, the TUI must be suspended meanwhile.
+// An empty Command runs the first shell available in the container.
+type RequestContainerExec struct {
+	ContainerID dto.ContainerID
+	Command     []string
+	Response    chan error
+}
+
+func (p *RequestContainerExec) isRequestData() {}
+
+type RequestContainerInspect struct {
+	ContainerID dto.ContainerID
+	Response    chan ContainerInspectResponse
+}
+
+func (p *RequestContainerInspect) isRequestData() {}
+
+type ContainerInspectResponse struct {
+	Detail dto.ContainerDetail
+	Err    error
+}
+
+// RequestHistory returns the metric history of every container of a project, or of all containers when ProjectID is empty.
+type RequestHistory struct {
+	ProjectID dto.ProjectID
+	Response  chan dto.MetricHistory
+}
+
+func (p *RequestHistory) isRequestData() {}
+
+type RequestGrouping struct {
+	Grouping dto.Grouping
+}
+
+func (p *RequestGrouping) isRequestData() {}
+
+// RequestTimeline returns the recorded events, oldest first, of a project and of a container when they are set.
+type RequestTimeline struct {
+	ProjectID   dto.ProjectID
+	ContainerID dto.ContainerID
+	Response    chan []dto.Event
+}
+
+func (p *RequestTimeline) isRequestData() {}
+
+type ProjectActionProgress struct {
+	ContainerName string
+	Total         int
+	Err           error
+}
+
 type Tui struct {
 	app                    *tview.Application
+	pages                  *tview.Pages
+	layout                 *tview.Flex
+	status                 *tview.TextView
 	tableProject           *tview.Table
 	tableProjectData       map[dto.ProjectID]dto.Project
 	tableProjectDataLock   sync.RWMutex
 	tableContainer         *tview.Table
 	tableContainerData     map[dto.ContainerID]dto.Container
 	tableContainerDataLock sync.RWMutex
+	tableAllContainers     *tview.Table
+	allContainers          map[dto.ContainerID]dto.Container
+	viewMode               viewMode
+	tableTree              *tview.Table
+	treeExpanded           map[dto.ProjectID]bool
+	treeSort               sortState
+	treeFilter             string
 	currentView            currentView
 	currentViewLock        sync.RWMutex
-	currentIDTargeted      string
+	currentIDTargeted      dto.ProjectID
+	memoryAbsolute         bool
+	showOptionalColumns    bool
+	projectSort            sortState
+	containerSort          sortState
+	projectFilter          string
+	containerFilter        string
+	allContainersSort      sortState
+	allContainersFilter    string
+	grouping               dto.Grouping
+	history                dto.MetricHistory
+	historyLock            sync.RWMutex
+	logs                   *logView
+	view                   tview.Primitive
+	split                  *tview.Flex
+	splitContainers        *tview.Flex
+	splitShown             bool
+	wide                   atomic.Bool
+	preview                *logPreview
+	execCommand            []string
 	requestData            chan RequestData
+	ctx                    context.Context
 	logger                 *slog.Logger
 }
 
-func NewTui(logger *slog.Logger) *Tui {
+func NewTui(logger *slog.Logger, execCommand []string) *Tui {
 	tview.Borders.HorizontalFocus = tview.BoxDrawingsLightHorizontal
 	tview.Borders.VerticalFocus = tview.BoxDrawingsLightVertical
 	tview.Borders.TopLeftFocus = tview.BoxDrawingsLightDownAndRight
@@ -72,159 +202,274 @@ func NewTui(logger *slog.Logger) *Tui {
 	tableContainer := tview.NewTable().SetSelectable(true, false)
 	tableContainer.SetBorder(true)
 
+	tableAllContainers := tview.NewTable().SetSelectable(true, false)
+	tableAllContainers.SetBorder(true)
+
+	tableTree := tview.NewTable().SetSelectable(true, false)
+	tableTree.SetBorder(true)
+
+	status := tview.NewTextView().SetDynamicColors(true)
+	layout := tview.NewFlex().SetDirection(tview.FlexRow)
+	pages := tview.NewPages().AddPage(pageMain, layout, true, true)
+
 	tui := &Tui{
 		app:                app,
+		pages:              pages,
+		layout:             layout,
+		status:             status,
 		logger:             logger,
 		tableProject:       tableProject,
 		tableProjectData:   make(map[dto.ProjectID]dto.Project),
 		tableContainer:     tableContainer,
 		tableContainerData: make(map[dto.ContainerID]dto.Container),
+		tableAllContainers: tableAllContainers,
+		tableTree:          tableTree,
+		treeExpanded:       make(map[dto.ProjectID]bool),
 		requestData:        make(chan RequestData),
 		currentView:        viewProjectList,
+		projectSort:        defaultSort,
+		containerSort:      defaultSort,
+		allContainersSort:  defaultAllContainersSort,
+		treeSort:           defaultSort,
+		execCommand:        execCommand,
 	}
 
 	tableProject.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		if event.Key() == tcell.KeyEnter || event.Key() == tcell.KeyRight {
-			rowIndex, _ := tableProject.GetSelection()
-			tui.tableProjectDataLock.RLock()
-			for _, project := range tui.tableProjectData {
-				if project.Name == tableProject.GetCell(rowIndex, 0).Text {
-					tui.currentIDTargeted = string(project.ID)
-					tui.currentViewLock.Lock()
-					tui.currentView = viewProject
-					tui.currentViewLock.Unlock()
-					break
-				}
+			project, ok := tui.selectedProject()
+			if !ok {
+				return nil
 			}
-			tui.tableProjectDataLock.RUnlock()
+
+			tui.setTargetedProjectID(project.ID)
+			tui.containerFilter = ""
+			tui.currentViewLock.Lock()
+			tui.currentView = viewProject
+			tui.currentViewLock.Unlock()
+
 			tui.tableContainer.Clear()
+			tui.tableContainer.Select(0, 0)
 			tui.drawContainers()
-			tui.app.SetRoot(tui.tableContainer, true)
+			tui.setView(tui.tableContainer)
+			return nil
+		}
+
+		if event.Key() == tcell.KeyRune && event.Rune() == 'v' {
+			tui.cycleViewMode()
+			return nil
+		}
+
+		if event.Key() == tcell.KeyRune && event.Rune() == 'P' {
+			tui.togglePreview()
+			return nil
 		}
 
-		return event
+		return tui.projectKeys(event, tableProject, func(key rune) {
+			tui.projectSort = cycleSort(tui, projectColumns, tui.projectSort, key)
+		}, &tui.projectFilter, tui.redrawProjects)
 	})
 
 	tableContainer.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		if event.Key() == tcell.KeyEsc || event.Key() == tcell.KeyLeft {
-			tui.app.SetRoot(tui.tableProject, true)
+			tui.setView(tui.tableProject)
 			tui.currentViewLock.Lock()
 			tui.currentView = viewProjectList
 			tui.currentViewLock.Unlock()
-			tui.currentIDTargeted = ""
+
+			// The split layout keeps showing the containers of the selected project.
+			if !tui.wide.Load() {
+				tui.setTargetedProjectID("")
+			}
+			return nil
 		}
 
-		return event
+		if event.Key() == tcell.KeyRune && event.Rune() == 'P' {
+			tui.togglePreview()
+			return nil
+		}
+
+		return tui.containerKeys(event, tableContainer, func(key rune) {
+			tui.containerSort = cycleSort(tui, containerColumns, tui.containerSort, key)
+		}, &tui.containerFilter, tui.drawContainers)
 	})
 
+	tableAllContainers.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyRune && event.Rune() == 'v' {
+			tui.cycleViewMode()
+			return nil
+		}
+
+		return tui.containerKeys(event, tableAllContainers, func(key rune) {
+			tui.allContainersSort = cycleSort(tui, allContainerColumns, tui.allContainersSort, key)
+		}, &tui.allContainersFilter, tui.redrawAllContainers)
+	})
+
+	tableTree.SetInputCapture(tui.treeKeys)
+
+	tableProject.SetMouseCapture(sortOnHeaderClick(tableProject, projectColumns, &tui.projectSort, tui.redrawProjects))
+	tableContainer.SetMouseCapture(sortOnHeaderClick(tableContainer, containerColumns, &tui.containerSort, tui.drawContainers))
+	tableAllContainers.SetMouseCapture(sortOnHeaderClick(tableAllContainers, allContainerColumns, &tui.allContainersSort, tui.redrawAllContainers))
+	tableTree.SetMouseCapture(sortOnHeaderClick(tableTree, projectColumns, &tui.treeSort, tui.redrawTree))
+
+	tui.splitContainers = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(tableContainer, 0, 1, false)
+	tui.split = tview.NewFlex().
+		AddItem(tableProject, 0, 1, true).
+		AddItem(tui.splitContainers, 0, 1, false)
+	tui.preview = newLogPreview()
+
+	tableProject.SetSelectionChangedFunc(func(row int, column int) {
+		tui.followProjectSelection()
+	})
+
+	tableContainer.SetSelectionChangedFunc(func(row int, column int) {
+		tui.updatePreview()
+	})
+
+	app.SetBeforeDrawFunc(func(screen tcell.Screen) bool {
+		width, _ := screen.Size()
+		tui.watchWidth(width)
+		return false
+	})
+
+	tui.setView(tableProject)
+
 	return tui
 }
 
+// On wide terminals the project and container tables are shown side by side.
+func (t *Tui) setView(view tview.Primitive) {
+	t.view = view
+
+	content := view
+	if t.wide.Load() && (view == t.tableProject || view == t.tableContainer) {
+		content = t.split
+	}
+
+	t.layout.Clear()
+	t.layout.AddItem(content, 0, 1, true)
+	t.layout.AddItem(t.status, 1, 0, false)
+	t.app.SetFocus(view)
+
+	t.splitShown = content == t.split
+	t.updatePreview()
+}
+
 func (t *Tui) RenderProjectHeader() {
-	t.tableProject.SetCell(0, 0, tview.NewTableCell("[::b]Project").SetAlign(tview.AlignCenter).SetExpansion(3).SetSelectable(false))
-	t.tableProject.SetCell(0, 1, tview.NewTableCell("[::b]CPU").SetAlign(tview.AlignRight).SetExpansion(2).SetMaxWidth(7).SetSelectable(false))
-	t.tableProject.SetCell(0, 2, tview.NewTableCell("[::b]Memory").SetAlign(tview.AlignRight).SetExpansion(2).SetMaxWidth(7).SetSelectable(false))
-	t.tableProject.SetCell(0, 3, tview.NewTableCell("[::b]Cont.").SetAlign(tview.AlignRight).SetExpansion(2).SetSelectable(false))
-	t.tableProject.SetFixed(1, 0)
+	renderHeader(t, t.tableProject, projectColumns, t.projectSort, groupingTitle(t.grouping))
 }
 
 func (t *Tui) RenderContainerHeader(project string) {
-	t.tableContainer.SetCell(0, 0, tview.NewTableCell("[::b]"+project+" container").SetAlign(tview.AlignCenter).SetExpansion(2).SetSelectable(false))
-	t.tableContainer.SetCell(0, 1, tview.NewTableCell("[::b]CPU").SetAlign(tview.AlignRight).SetExpansion(2).SetMaxWidth(7).SetSelectable(false))
-	t.tableContainer.SetCell(0, 2, tview.NewTableCell("[::b]Memory").SetAlign(tview.AlignRight).SetExpansion(2).SetMaxWidth(7).SetSelectable(false))
-	t.tableContainer.SetFixed(1, 0)
+	renderHeader(t, t.tableContainer, containerColumns, t.containerSort, project+" container")
 }
 
-func (t *Tui) drawProjects() {
-	projects := slices.Collect(maps.Values(t.tableProjectData))
+func (t *Tui) formatMemory(percentage float64, usage float64, limit float64) string {
+	if !t.memoryAbsolute {
+		return fmt.Sprintf("%.2f%%", max(0, percentage))
+	}
 
-	slices.SortStableFunc(projects, func(a, b dto.Project) int {
-		if a.CPUPercentage < b.CPUPercentage {
-			return 1
-		}
+	if limit == 0 {
+		return formatBytes(usage) + " / ∞"
+	}
 
-		if a.CPUPercentage > b.CPUPercentage {
-			return -1
+	return formatBytes(usage) + " / " + formatBytes(limit)
+}
+
+func (t *Tui) redrawProjects() {
+	t.tableProjectDataLock.RLock()
+	defer t.tableProjectDataLock.RUnlock()
+
+	t.drawProjects()
+}
+
+func (t *Tui) drawProjects() {
+	var projects []dto.Project
+	for _, project := range t.tableProjectData {
+		if projectMatchesFilter(t.projectFilter, project) {
+			projects = append(projects, project)
 		}
+	}
 
-		return strings.Compare(a.Name, b.Name)
-	})
+	sortRows(t, projects, projectColumns, t.projectSort)
 
+	selected, hasSelection := selectedReference[dto.ProjectID](t.tableProject)
 	t.tableProject.Clear()
 	t.RenderProjectHeader()
-	offset := 0
 	for index, project := range projects {
-		t.tableProject.SetCell(index+1+offset, 0, tview.NewTableCell(project.Name))
-		t.tableProject.SetCell(
-			index+1+offset,
-			1,
-			tview.NewTableCell(
-				fmt.Sprintf("%.2f%%", max(0, project.CPUPercentage)),
-			).
-				SetAlign(tview.AlignRight),
-		)
-		t.tableProject.SetCell(
-			index+1+offset,
-			2,
-			tview.NewTableCell(
-				fmt.Sprintf("%.2f%%", max(0, project.MemoryPercentage)),
-			).
-				SetAlign(tview.AlignRight),
-		)
-		t.tableProject.SetCell(
-			index+1+offset,
-			3,
-			tview.NewTableCell(
-				fmt.Sprintf("%d/%d", project.ContainersRunning, len(project.ContainersState)),
-			).
-				SetAlign(tview.AlignRight),
-		)
+		renderRow(t, t.tableProject, projectColumns, index+1, project, project.ID)
+
+		if indexes, ok := fuzzyMatch(t.projectFilter, project.Name); ok {
+			cell := t.tableProject.GetCell(index+1, 0)
+			cell.SetText(highlightMatches(project.Name, indexes, cell.Color))
+		}
+	}
+
+	t.tableProject.SetTitle(filterTitle(t.projectFilter))
+
+	if hasSelection {
+		selectReference(t.tableProject, selected)
+	} else if len(projects) > 0 {
+		t.tableProject.Select(1, 0)
 	}
 }
 
 func (t *Tui) drawContainers() {
-	t.tableContainerDataLock.RLock()
-	containers := slices.SortedStableFunc(maps.Values(t.tableContainerData), func(a, b dto.Container) int {
-		if a.CPUPercentage < b.CPUPercentage {
-			return 1
-		}
+	projectID := t.targetedProjectID()
 
-		if a.CPUPercentage > b.CPUPercentage {
-			return -1
+	t.tableContainerDataLock.RLock()
+	var containers []dto.Container
+	for _, container := range t.tableContainerData {
+		if container.Project.ID == projectID && containerMatchesFilter(t.containerFilter, container) {
+			containers = append(containers, container)
 		}
-
-		return strings.Compare(a.Name, b.Name)
-	})
+	}
 	t.tableContainerDataLock.RUnlock()
 
+	sortRows(t, containers, containerColumns, t.containerSort)
+
+	selected, hasSelection := selectedReference[dto.ContainerID](t.tableContainer)
 	t.tableContainer.Clear()
 	t.tableProjectDataLock.RLock()
-	t.RenderContainerHeader(t.tableProjectData[dto.ProjectID(t.currentIDTargeted)].Name)
+	t.RenderContainerHeader(t.tableProjectData[projectID].Name)
 	t.tableProjectDataLock.RUnlock()
-	index := 0
-	for _, container := range containers {
-		if string(container.Project.ID) != t.currentIDTargeted {
-			continue
+	for index, container := range containers {
+		renderRow(t, t.tableContainer, containerColumns, index+1, container, container.ID)
+
+		if indexes, ok := fuzzyMatch(t.containerFilter, container.Service); ok {
+			cell := t.tableContainer.GetCell(index+1, 0)
+			cell.SetText(highlightMatches(container.Service, indexes, cell.Color))
 		}
-		index += 1
-
-		t.tableContainer.SetCell(index, 0, tview.NewTableCell(container.Service))
-		t.tableContainer.SetCell(
-			index,
-			1,
-			tview.NewTableCell(
-				fmt.Sprintf("%.2f%%", container.CPUPercentage),
-			).
-				SetAlign(tview.AlignRight),
-		)
-		t.tableContainer.SetCell(
-			index,
-			2,
-			tview.NewTableCell(
-				fmt.Sprintf("%.2f%%", container.MemoryPercentage),
-			).
-				SetAlign(tview.AlignRight),
-		)
+	}
+
+	t.tableContainer.SetTitle(filterTitle(t.containerFilter))
+
+	if hasSelection {
+		selectReference(t.tableContainer, selected)
+	}
+}
+
+// toggleOptionalColumns falls back to the default sort when the sorted column gets hidden.
+func (t *Tui) toggleOptionalColumns() {
+	t.showOptionalColumns = !t.showOptionalColumns
+	if t.showOptionalColumns {
+		return
+	}
+
+	if projectColumns[t.projectSort.column].optional {
+		t.projectSort = defaultSort
+	}
+
+	if containerColumns[t.containerSort.column].optional {
+		t.containerSort = defaultSort
+	}
+
+	if allContainerColumns[t.allContainersSort.column].optional {
+		t.allContainersSort = defaultAllContainersSort
+	}
+
+	if projectColumns[t.treeSort.column].optional {
+		t.treeSort = defaultSort
 	}
 }
 
@@ -247,50 +492,99 @@ func (t *Tui) getData(ctx context.Context) {
 			t.currentViewLock.RUnlock()
 			switch cv {
 			case viewProjectList:
-				response := make(chan []dto.Project)
-				t.requestData <- &RequestProjectList{
-					Response: response,
-				}
-
-				projects := <-response
-				t.tableProjectDataLock.Lock()
-				t.tableProjectData = make(map[dto.ProjectID]dto.Project)
-				for _, p := range projects {
-					t.tableProjectData[p.ID] = p
-				}
-				t.tableProjectDataLock.Unlock()
-
-				t.app.QueueUpdateDraw(func() {
-					t.drawProjects()
-				})
+				t.refreshProjects(t.drawProjects)
+			case viewContainerList:
+				t.refreshProjects(t.drawAllContainers)
+			case viewProjectTree:
+				t.refreshProjects(t.drawTree)
 			case viewProject:
-				response := make(chan []dto.Container)
-				t.requestData <- &RequestProject{
-					ProjectID: dto.ProjectID(t.currentIDTargeted),
-					Response:  response,
-				}
+				t.refreshContainers()
+			}
 
-				containers := <-response
-				t.tableContainerDataLock.Lock()
-				t.tableContainerData = make(map[dto.ContainerID]dto.Container)
-				for _, c := range containers {
-					t.tableContainerData[c.ID] = c
+			// The split layout shows both the project list and the containers of the selected project.
+			if t.wide.Load() && (cv == viewProjectList || cv == viewProject) {
+				if cv == viewProjectList {
+					t.refreshContainers()
+				} else {
+					t.refreshProjects(t.drawProjects)
 				}
-				t.tableContainerDataLock.Unlock()
-
-				t.app.QueueUpdateDraw(func() {
-					t.drawContainers()
-				})
 			}
 		}
 	}
 }
 
+func (t *Tui) refreshContainers() {
+	projectID := t.targetedProjectID()
+
+	response := make(chan []dto.Container)
+	t.requestData <- &RequestProject{
+		ProjectID: projectID,
+		Response:  response,
+	}
+
+	containers := <-response
+	t.tableContainerDataLock.Lock()
+	t.tableContainerData = make(map[dto.ContainerID]dto.Container)
+	for _, c := range containers {
+		t.tableContainerData[c.ID] = c
+	}
+	t.tableContainerDataLock.Unlock()
+
+	// The project list, shown aside in the split layout, needs the history of every project.
+	if !t.wide.Load() {
+		t.fetchHistory(projectID)
+	}
+
+	t.app.QueueUpdateDraw(func() {
+		t.drawContainers()
+	})
+}
+
+// refreshProjects fetches the project list then calls draw with t.tableProjectDataLock held.
+func (t *Tui) refreshProjects(draw func()) {
+	response := make(chan []dto.Project)
+	t.requestData <- &RequestProjectList{
+		Response: response,
+	}
+
+	projects := <-response
+	t.tableProjectDataLock.Lock()
+	t.tableProjectData = make(map[dto.ProjectID]dto.Project)
+	for _, p := range projects {
+		t.tableProjectData[p.ID] = p
+	}
+	t.tableProjectDataLock.Unlock()
+
+	t.fetchHistory("")
+
+	t.app.QueueUpdateDraw(func() {
+		t.tableProjectDataLock.RLock()
+		defer t.tableProjectDataLock.RUnlock()
+
+		draw()
+	})
+}
+
 func (t *Tui) Render(ctx context.Context) {
+	t.ctx = ctx
 	go t.getData(ctx)
 
-	if err := t.app.SetRoot(t.tableProject, true).EnableMouse(true).Run(); err != nil {
+	if err := t.app.SetRoot(t.pages, true).EnableMouse(true).Run(); err != nil {
 		t.logger.ErrorContext(ctx, "error rendering tui", slog.Any("error", err.Error()))
 		os.Exit(1)
 	}
 }
+
+// targetedProjectID guards currentIDTargeted with currentViewLock, the refresh goroutines read it.
+func (t *Tui) targetedProjectID() dto.ProjectID {
+	t.currentViewLock.RLock()
+	defer t.currentViewLock.RUnlock()
+
+	return t.currentIDTargeted
+}
+
+func (t *Tui) setTargetedProjectID(projectID dto.ProjectID) {
+	t.currentViewLock.Lock()
+	t.currentIDTargeted = projectID
+	t.currentViewLock.Unlock()
+}